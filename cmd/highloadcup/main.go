@@ -0,0 +1,117 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/disc/highloadcup/httpapi"
+	"github.com/disc/highloadcup/loader"
+	"github.com/disc/highloadcup/store"
+	"github.com/valyala/fasthttp"
+)
+
+var (
+	addr            = flag.String("addr", ":80", "TCP address to listen to")
+	closeAfterWrite = flag.Bool("closeAfterWrite", false, "Close the connection after every create/update response")
+	historySize     = flag.Int("historySize", 16, "Number of past revisions kept per entity")
+	changeFeedSize  = flag.Int("changeFeedSize", 100000, "Number of recent changes kept for /changes readers")
+	bareErrors      = flag.Bool("bareErrors", false, "Answer errors with a bare {} body instead of the error envelope")
+	storage         = flag.String("storage", "memory", "Where the entities are kept: memory or file")
+	denseIds        = flag.Bool("denseIds", false, "Keep the entities in slices indexed by id, read without locks")
+	storageDir      = flag.String("storageDir", "./storage/", "Directory of the entity files with -storage=file")
+
+	webhooksConfig    = flag.String("webhooks", "", "JSON file with webhooks to register on start")
+	webhookAttempts   = flag.Int("webhookAttempts", 5, "Delivery attempts per webhook call")
+	webhookBackoff    = flag.Duration("webhookBackoff", 500*time.Millisecond, "Delay before the first webhook retry, doubled on every next one")
+	webhookDeadLetter = flag.String("webhookDeadLetter", "webhooks-dead-letter.jsonl", "File collecting undeliverable webhook calls")
+)
+
+func main() {
+	start := time.Now()
+	fmt.Println("Started")
+
+	flag.Parse()
+	if *webhookAttempts < 1 {
+		log.Fatalf("webhookAttempts must be at least 1, got %d", *webhookAttempts)
+	}
+
+	s := store.NewStore(*historySize)
+	if *denseIds {
+		s = store.NewDenseStore(*historySize)
+	}
+	// loaded is set for a file store that holds the data set already.
+	var loaded bool
+	switch *storage {
+	case "memory":
+	case "file":
+		// Files without the marker are from a load that did not finish, and
+		// the data set is loaded again from scratch.
+		if loaded = store.FileStoreLoaded(*storageDir); !loaded {
+			if err := store.RemoveFileStore(*storageDir); err != nil {
+				log.Fatalf("Error in clearing the store: %s", err)
+			}
+		}
+		var err error
+		if s, err = store.OpenFileStore(*storageDir, s); err != nil {
+			log.Fatalf("Error in opening the store: %s", err)
+		}
+	default:
+		log.Fatalf("Unknown storage %s", *storage)
+	}
+
+	// The files of a file store are flushed to disk and closed on shutdown.
+	shutdown := make(chan os.Signal, 1)
+	signal.Notify(shutdown, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-shutdown
+		if err := s.Close(); err != nil {
+			log.Fatalf("Error in closing the store: %s", err)
+		}
+		os.Exit(0)
+	}()
+
+	if !loaded {
+		skipped, err := loader.ParseDataDir(s, "./data/")
+		if err != nil {
+			log.Fatalf("Error in loading the data: %s", err)
+		}
+		if skipped > 0 {
+			log.Printf("Skipped %d invalid entities", skipped)
+		}
+		if *storage == "file" {
+			if err := store.MarkFileStoreLoaded(*storageDir, s); err != nil {
+				log.Fatalf("Error in marking the store loaded: %s", err)
+			}
+		}
+	} else if err := loader.ParseOptions(s, "./data/options.txt"); err != nil {
+		log.Fatalf("Error in loading the options: %s", err)
+	}
+
+	fmt.Println("Parsing completed at " + time.Since(start).String())
+
+	srv := httpapi.NewServer(s, *changeFeedSize)
+	srv.CloseAfterWrite = *closeAfterWrite
+	srv.BareErrors = *bareErrors
+
+	srv.Webhooks.Attempts = *webhookAttempts
+	srv.Webhooks.Backoff = *webhookBackoff
+	srv.Webhooks.DeadLetter = *webhookDeadLetter
+	if *webhooksConfig != "" {
+		if err := srv.Webhooks.LoadFile(*webhooksConfig); err != nil {
+			log.Fatalf("Error in webhooks config: %s", err)
+		}
+	}
+	go srv.Webhooks.Run(srv.Changes, srv.Changes.Last())
+
+	h := srv.HandleRequest
+
+	if err := fasthttp.ListenAndServe(*addr, h); err != nil {
+		s.Close()
+		log.Fatalf("Error in ListenAndServe: %s", err)
+	}
+}
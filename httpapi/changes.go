@@ -0,0 +1,206 @@
+package httpapi
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/mailru/easyjson"
+	"github.com/valyala/fasthttp"
+)
+
+const (
+	changeCreate = "create"
+	changeUpdate = "update"
+
+	changesKeepAlive      = 15 * time.Second
+	changesDefaultTimeout = 30
+	changesMaxTimeout     = 120
+)
+
+//easyjson:json
+type Change struct {
+	Seq     uint64          `json:"seq"`
+	Kind    string          `json:"kind"`
+	Entity  string          `json:"entity"`
+	Id      uint            `json:"id"`
+	Version uint            `json:"version"`
+	Data    json.RawMessage `json:"data"`
+	Prev    json.RawMessage `json:"prev,omitempty"`
+}
+
+//easyjson:json
+type Changes struct {
+	Changes []Change `json:"changes"`
+	Last    uint64   `json:"last"`
+}
+
+// ChangeFeed keeps the most recent mutations in sequence order. Readers wait
+// on the notify channel, which is closed and replaced on every publish.
+type ChangeFeed struct {
+	changes []Change
+	size    int
+	seq     uint64
+	notify  chan struct{}
+	sync.Mutex
+}
+
+func newChangeFeed(size int) *ChangeFeed {
+	return &ChangeFeed{size: size, notify: make(chan struct{})}
+}
+
+// Publish appends a change. Updates carry the replaced entity as prev.
+func (f *ChangeFeed) Publish(kind string, entity string, id uint, version uint, data json.Marshaler, prev json.Marshaler) {
+	raw, _ := data.MarshalJSON()
+	var rawPrev json.RawMessage
+	if prev != nil {
+		rawPrev, _ = prev.MarshalJSON()
+	}
+
+	f.Lock()
+	f.seq++
+	f.changes = append(f.changes, Change{f.seq, kind, entity, id, version, raw, rawPrev})
+	if len(f.changes) > f.size {
+		f.changes = f.changes[len(f.changes)-f.size:]
+	}
+	close(f.notify)
+	f.notify = make(chan struct{})
+	f.Unlock()
+}
+
+// observe publishes the writes of the store. It runs under the write lock of
+// the repository, so the changes of an entity are numbered in version order.
+func (f *ChangeFeed) observe(entity string, id uint, version uint, data json.Marshaler, prev json.Marshaler) {
+	kind := changeUpdate
+	if prev == nil {
+		kind = changeCreate
+	}
+	f.Publish(kind, entity, id, version, data, prev)
+}
+
+// Last returns the sequence number of the latest change.
+func (f *ChangeFeed) Last() uint64 {
+	f.Lock()
+	defer f.Unlock()
+
+	return f.seq
+}
+
+// Since returns the changes after the given sequence number and a channel
+// closed on the next publish. ok is false when changes after seq have
+// already been dropped from the feed.
+func (f *ChangeFeed) Since(seq uint64) (changes []Change, ok bool, wait <-chan struct{}) {
+	f.Lock()
+	defer f.Unlock()
+
+	if seq > f.seq {
+		seq = f.seq
+	}
+	if len(f.changes) == 0 || f.changes[0].Seq > seq+1 {
+		return nil, len(f.changes) == 0 && seq == f.seq, f.notify
+	}
+	return f.changes[seq+1-f.changes[0].Seq:], true, f.notify
+}
+
+// parseChangesSince reads the resume point from the since argument or, for
+// reconnecting event sources, the Last-Event-ID header.
+func parseChangesSince(ctx *fasthttp.RequestCtx) (uint64, error) {
+	since := ctx.QueryArgs().Peek("since")
+	if len(since) == 0 {
+		since = ctx.Request.Header.Peek("Last-Event-ID")
+	}
+	if len(since) == 0 {
+		return 0, nil
+	}
+	seq, err := strconv.ParseUint(string(since), 10, 64)
+	if err != nil {
+		return 0, &QueryError{"since", "must be a change sequence number"}
+	}
+	return seq, nil
+}
+
+func (srv *Server) changesStreamRequestHandler(ctx *fasthttp.RequestCtx) {
+	since, err := parseChangesSince(ctx)
+	if err != nil {
+		srv.badRequest(ctx, err)
+		return
+	}
+	if _, ok, _ := srv.Changes.Since(since); !ok {
+		srv.writeError(ctx, 410, ErrorBody{Code: errorGone, Message: "the changes since this point have been dropped"})
+		return
+	}
+
+	ctx.SetContentType("text/event-stream")
+	ctx.Response.Header.Set("Cache-Control", "no-cache")
+	ctx.SetBodyStreamWriter(func(w *bufio.Writer) {
+		for {
+			pending, ok, wait := srv.Changes.Since(since)
+			if !ok {
+				// The client fell too far behind; it has to resync.
+				fmt.Fprint(w, "event: reset\ndata: {}\n\n")
+				w.Flush()
+				return
+			}
+			for _, change := range pending {
+				event, _ := easyjson.Marshal(change)
+				fmt.Fprintf(w, "id: %d\nevent: %s.%s\ndata: %s\n\n", change.Seq, change.Entity, change.Kind, event)
+				since = change.Seq
+			}
+			if err := w.Flush(); err != nil {
+				return
+			}
+
+			select {
+			case <-wait:
+			case <-time.After(changesKeepAlive):
+				fmt.Fprint(w, ": keep-alive\n\n")
+				if err := w.Flush(); err != nil {
+					return
+				}
+			}
+		}
+	})
+}
+
+func (srv *Server) changesPollRequestHandler(ctx *fasthttp.RequestCtx) {
+	since, err := parseChangesSince(ctx)
+	if err != nil {
+		srv.badRequest(ctx, err)
+		return
+	}
+	timeout := changesDefaultTimeout
+	if args := ctx.QueryArgs(); args.Has("timeout") {
+		if timeout, err = strconv.Atoi(string(args.Peek("timeout"))); err != nil || timeout < 0 {
+			srv.badRequest(ctx, &QueryError{"timeout", "must be a non-negative number of seconds"})
+			return
+		}
+		if timeout > changesMaxTimeout {
+			timeout = changesMaxTimeout
+		}
+	}
+
+	pending, ok, wait := srv.Changes.Since(since)
+	if ok && len(pending) == 0 && timeout > 0 {
+		select {
+		case <-wait:
+		case <-time.After(time.Duration(timeout) * time.Second):
+		}
+		pending, ok, _ = srv.Changes.Since(since)
+	}
+	if !ok {
+		srv.writeError(ctx, 410, ErrorBody{Code: errorGone, Message: "the changes since this point have been dropped"})
+		return
+	}
+
+	last := since
+	if len(pending) > 0 {
+		last = pending[len(pending)-1].Seq
+	} else {
+		pending = []Change{}
+	}
+	response, _ := easyjson.Marshal(Changes{pending, last})
+	ctx.Success("application/json", response)
+}
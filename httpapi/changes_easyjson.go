@@ -0,0 +1,262 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package httpapi
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson4ce3cd59DecodeGithubComDiscHighloadcupHttpapi(in *jlexer.Lexer, out *Changes) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "changes":
+			if in.IsNull() {
+				in.Skip()
+				out.Changes = nil
+			} else {
+				in.Delim('[')
+				if out.Changes == nil {
+					if !in.IsDelim(']') {
+						out.Changes = make([]Change, 0, 0)
+					} else {
+						out.Changes = []Change{}
+					}
+				} else {
+					out.Changes = (out.Changes)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 Change
+					if in.IsNull() {
+						in.Skip()
+					} else {
+						(v1).UnmarshalEasyJSON(in)
+					}
+					out.Changes = append(out.Changes, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "last":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Last = uint64(in.Uint64())
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson4ce3cd59EncodeGithubComDiscHighloadcupHttpapi(out *jwriter.Writer, in Changes) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"changes\":"
+		out.RawString(prefix[1:])
+		if in.Changes == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v2, v3 := range in.Changes {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				(v3).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"last\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.Last))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Changes) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson4ce3cd59EncodeGithubComDiscHighloadcupHttpapi(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Changes) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4ce3cd59EncodeGithubComDiscHighloadcupHttpapi(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Changes) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson4ce3cd59DecodeGithubComDiscHighloadcupHttpapi(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Changes) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4ce3cd59DecodeGithubComDiscHighloadcupHttpapi(l, v)
+}
+func easyjson4ce3cd59DecodeGithubComDiscHighloadcupHttpapi1(in *jlexer.Lexer, out *Change) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "seq":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Seq = uint64(in.Uint64())
+			}
+		case "kind":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Kind = string(in.String())
+			}
+		case "entity":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Entity = string(in.String())
+			}
+		case "id":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Id = uint(in.Uint())
+			}
+		case "version":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Version = uint(in.Uint())
+			}
+		case "data":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				if data := in.Raw(); in.Ok() {
+					in.AddError((out.Data).UnmarshalJSON(data))
+				}
+			}
+		case "prev":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				if data := in.Raw(); in.Ok() {
+					in.AddError((out.Prev).UnmarshalJSON(data))
+				}
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson4ce3cd59EncodeGithubComDiscHighloadcupHttpapi1(out *jwriter.Writer, in Change) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"seq\":"
+		out.RawString(prefix[1:])
+		out.Uint64(uint64(in.Seq))
+	}
+	{
+		const prefix string = ",\"kind\":"
+		out.RawString(prefix)
+		out.String(string(in.Kind))
+	}
+	{
+		const prefix string = ",\"entity\":"
+		out.RawString(prefix)
+		out.String(string(in.Entity))
+	}
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix)
+		out.Uint(uint(in.Id))
+	}
+	{
+		const prefix string = ",\"version\":"
+		out.RawString(prefix)
+		out.Uint(uint(in.Version))
+	}
+	{
+		const prefix string = ",\"data\":"
+		out.RawString(prefix)
+		out.Raw((in.Data).MarshalJSON())
+	}
+	if len(in.Prev) != 0 {
+		const prefix string = ",\"prev\":"
+		out.RawString(prefix)
+		out.Raw((in.Prev).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Change) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson4ce3cd59EncodeGithubComDiscHighloadcupHttpapi1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Change) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4ce3cd59EncodeGithubComDiscHighloadcupHttpapi1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Change) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson4ce3cd59DecodeGithubComDiscHighloadcupHttpapi1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Change) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4ce3cd59DecodeGithubComDiscHighloadcupHttpapi1(l, v)
+}
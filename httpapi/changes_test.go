@@ -0,0 +1,197 @@
+package httpapi
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/disc/highloadcup/model"
+	"github.com/disc/highloadcup/store"
+	"github.com/mailru/easyjson"
+	"github.com/valyala/fasthttp"
+)
+
+// newChangesServer keeps three changes out of the five writes it makes:
+// user 1 is created and updated, then visit 1 is created and updated twice.
+func newChangesServer() *Server {
+	srv := NewServer(store.NewStore(0), 3)
+	srv.store.Users.Update(model.User{Id: 1, Email: "a@example.com", Gender: "m"})
+	srv.store.Users.Update(model.User{Id: 1, Email: "a@example.com", Gender: "f"})
+	for mark := uint8(1); mark <= 3; mark++ {
+		srv.store.Visits.Update(model.Visit{Id: 1, Location: 1, User: 1, Visited_at: 1000000000, Mark: mark})
+	}
+	return srv
+}
+
+func TestChangesPoll(t *testing.T) {
+	t.Parallel()
+	srv := newChangesServer()
+
+	tests := []struct {
+		uri     string
+		status  int
+		seqs    []uint64
+		last    uint64
+		headers []string
+	}{
+		{"/changes/poll?since=0&timeout=0", 410, nil, 0, nil},
+		{"/changes/poll?since=1&timeout=0", 410, nil, 0, nil},
+		{"/changes/poll?since=2&timeout=0", 200, []uint64{3, 4, 5}, 5, nil},
+		{"/changes/poll?since=4&timeout=0", 200, []uint64{5}, 5, nil},
+		{"/changes/poll?since=5&timeout=0", 200, nil, 5, nil},
+		{"/changes/poll?timeout=0", 200, []uint64{4, 5}, 5, []string{"Last-Event-ID", "3"}},
+		{"/changes/poll?since=x", 400, nil, 0, nil},
+		{"/changes/poll?timeout=-1", 400, nil, 0, nil},
+	}
+	for _, test := range tests {
+		ctx := serveRequest(srv, "GET", test.uri, "", test.headers...)
+		if status := ctx.Response.StatusCode(); status != test.status {
+			t.Errorf("%s: got status %d, want %d", test.uri, status, test.status)
+			continue
+		}
+		if test.status != 200 {
+			continue
+		}
+		var changes Changes
+		if err := easyjson.Unmarshal(ctx.Response.Body(), &changes); err != nil {
+			t.Errorf("%s: %s", test.uri, err)
+			continue
+		}
+		var seqs []uint64
+		for _, change := range changes.Changes {
+			seqs = append(seqs, change.Seq)
+		}
+		if fmt.Sprint(seqs) != fmt.Sprint(test.seqs) || changes.Last != test.last {
+			t.Errorf("%s: got changes %v and last %d, want %v and %d", test.uri, seqs, changes.Last, test.seqs, test.last)
+		}
+	}
+
+	ctx := serveRequest(srv, "GET", "/changes/poll?since=2&timeout=0", "")
+	var changes Changes
+	easyjson.Unmarshal(ctx.Response.Body(), &changes)
+	if change := changes.Changes[0]; change.Kind != changeCreate || change.Entity != "visit" || change.Version != 1 || change.Prev != nil {
+		t.Errorf("got %+v for the visit create", change)
+	}
+	if change := changes.Changes[2]; change.Kind != changeUpdate || change.Version != 3 ||
+		!strings.Contains(string(change.Prev), `"mark":2`) || !strings.Contains(string(change.Data), `"mark":3`) {
+		t.Errorf("got %+v for the last visit update", change)
+	}
+}
+
+func TestChangesLongPoll(t *testing.T) {
+	t.Parallel()
+	srv := newChangesServer()
+
+	done := make(chan *fasthttp.RequestCtx)
+	go func() {
+		done <- serveRequest(srv, "GET", "/changes/poll?since=5&timeout=5", "")
+	}()
+	time.Sleep(50 * time.Millisecond)
+	srv.store.Locations.Update(model.Location{Id: 1, Place: "Парк"})
+
+	select {
+	case ctx := <-done:
+		var changes Changes
+		if err := easyjson.Unmarshal(ctx.Response.Body(), &changes); err != nil {
+			t.Fatal(err)
+		}
+		if len(changes.Changes) != 1 || changes.Changes[0].Entity != "location" || changes.Last != 6 {
+			t.Errorf("got %s", ctx.Response.Body())
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("long poll did not wake up on a write")
+	}
+}
+
+// readEvents reads the ids of the next n events of an event stream.
+func readEvents(t *testing.T, reader *bufio.Reader, n int) []string {
+	var ids []string
+	for len(ids) < n {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.HasPrefix(line, "id: ") {
+			ids = append(ids, strings.TrimSpace(line[len("id: "):]))
+		}
+	}
+	return ids
+}
+
+func TestChangesStream(t *testing.T) {
+	t.Parallel()
+	srv := newChangesServer()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	go fasthttp.Serve(ln, srv.HandleRequest)
+
+	stream := func(lastEventId string) (net.Conn, *bufio.Reader, string) {
+		conn, err := net.Dial("tcp", ln.Addr().String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		conn.SetDeadline(time.Now().Add(5 * time.Second))
+		fmt.Fprintf(conn, "GET /changes HTTP/1.1\r\nHost: test\r\nLast-Event-ID: %s\r\n\r\n", lastEventId)
+		reader := bufio.NewReader(conn)
+		status, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatal(err)
+		}
+		return conn, reader, status
+	}
+
+	conn, _, status := stream("1")
+	conn.Close()
+	if !strings.Contains(status, " 410 ") {
+		t.Errorf("resuming from a dropped change: got %s", status)
+	}
+
+	conn, reader, status := stream("3")
+	defer conn.Close()
+	if !strings.Contains(status, " 200 ") {
+		t.Fatalf("resuming from a kept change: got %s", status)
+	}
+	if ids := readEvents(t, reader, 2); fmt.Sprint(ids) != "[4 5]" {
+		t.Errorf("got events %v after Last-Event-ID 3", ids)
+	}
+	srv.store.Users.Update(model.User{Id: 2, Email: "b@example.com", Gender: "m"})
+	if ids := readEvents(t, reader, 1); fmt.Sprint(ids) != "[6]" {
+		t.Errorf("got events %v after a write", ids)
+	}
+}
+
+// TestChangesVersionOrder updates one visit from many goroutines at once and
+// expects the feed to number its versions in order.
+func TestChangesVersionOrder(t *testing.T) {
+	const writes = 200
+	t.Parallel()
+	srv := NewServer(store.NewStore(0), writes)
+
+	var wg sync.WaitGroup
+	for i := 0; i < writes; i++ {
+		wg.Add(1)
+		go func(mark uint8) {
+			defer wg.Done()
+			srv.store.Visits.Update(model.Visit{Id: 1, Location: 1, User: 1, Visited_at: 1000000000, Mark: mark})
+		}(uint8(i % 6))
+	}
+	wg.Wait()
+
+	changes, ok, _ := srv.Changes.Since(0)
+	if !ok || len(changes) != writes {
+		t.Fatalf("got %d changes", len(changes))
+	}
+	for i, change := range changes {
+		if change.Version != uint(i+1) {
+			t.Fatalf("change %d has version %d", change.Seq, change.Version)
+		}
+	}
+}
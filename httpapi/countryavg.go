@@ -0,0 +1,40 @@
+package httpapi
+
+import (
+	"github.com/disc/highloadcup/query"
+	"github.com/mailru/easyjson"
+	"github.com/valyala/fasthttp"
+)
+
+//easyjson:json
+type CountriesAvg struct {
+	Countries []query.CountryAvg `json:"countries"`
+}
+
+func (srv *Server) countriesRequestHandler(ctx *fasthttp.RequestCtx, args *fasthttp.Args) {
+	filters, ok := parseLocationAvgFilter(args)
+	if !ok {
+		srv.badRequest(ctx, errInvalidQuery)
+		return
+	}
+
+	response, _ := easyjson.Marshal(CountriesAvg{query.GetCountriesAvg(srv.store, srv.store.Locations.Ids(nil), filters)})
+	ctx.Success("application/json", response)
+}
+
+func (srv *Server) countryAvgRequestHandler(ctx *fasthttp.RequestCtx, country string, args *fasthttp.Args) {
+	ids := srv.store.Locations.Ids(&country)
+	if len(ids) == 0 {
+		srv.notFound(ctx)
+		return
+	}
+
+	filters, ok := parseLocationAvgFilter(args)
+	if !ok {
+		srv.badRequest(ctx, errInvalidQuery)
+		return
+	}
+
+	response, _ := easyjson.Marshal(query.GetCountriesAvg(srv.store, ids, filters)[0])
+	ctx.Success("application/json", response)
+}
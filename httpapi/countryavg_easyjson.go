@@ -0,0 +1,117 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package httpapi
+
+import (
+	json "encoding/json"
+	query "github.com/disc/highloadcup/query"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson766ea78aDecodeGithubComDiscHighloadcupHttpapi(in *jlexer.Lexer, out *CountriesAvg) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "countries":
+			if in.IsNull() {
+				in.Skip()
+				out.Countries = nil
+			} else {
+				in.Delim('[')
+				if out.Countries == nil {
+					if !in.IsDelim(']') {
+						out.Countries = make([]query.CountryAvg, 0, 1)
+					} else {
+						out.Countries = []query.CountryAvg{}
+					}
+				} else {
+					out.Countries = (out.Countries)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 query.CountryAvg
+					if in.IsNull() {
+						in.Skip()
+					} else {
+						(v1).UnmarshalEasyJSON(in)
+					}
+					out.Countries = append(out.Countries, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson766ea78aEncodeGithubComDiscHighloadcupHttpapi(out *jwriter.Writer, in CountriesAvg) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"countries\":"
+		out.RawString(prefix[1:])
+		if in.Countries == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v2, v3 := range in.Countries {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				(v3).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v CountriesAvg) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson766ea78aEncodeGithubComDiscHighloadcupHttpapi(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v CountriesAvg) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson766ea78aEncodeGithubComDiscHighloadcupHttpapi(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *CountriesAvg) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson766ea78aDecodeGithubComDiscHighloadcupHttpapi(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *CountriesAvg) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson766ea78aDecodeGithubComDiscHighloadcupHttpapi(l, v)
+}
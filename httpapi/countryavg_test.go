@@ -0,0 +1,47 @@
+package httpapi
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/mailru/easyjson"
+)
+
+func TestCountriesRouting(t *testing.T) {
+	t.Parallel()
+	srv := newLocationsServer()
+
+	tests := []struct {
+		uri    string
+		status int
+		body   string
+	}{
+		{"/countries", 200, ""},
+		{"/countries/", 200, ""},
+		{"/countries/avg", 404, ""},
+		{"/countries//avg", 404, ""},
+		{"/countries/X/avg", 404, ""},
+		{"/countries/" + url.PathEscape("Италия") + "/avg", 200, `{"country":"Италия","avg":1,"visits":1,"cities":{"Рим":1}}`},
+		{"/countries/" + url.PathEscape("Италия") + "/avg?gender=x", 400, ""},
+		{"/countries/" + url.PathEscape("Италия"), 404, ""},
+		{"/countries?gender=x", 400, ""},
+	}
+	for _, test := range tests {
+		ctx := serveRequest(srv, "GET", test.uri, "")
+		if status := ctx.Response.StatusCode(); status != test.status {
+			t.Errorf("%s: got status %d, want %d", test.uri, status, test.status)
+			continue
+		}
+		if body := string(ctx.Response.Body()); test.body != "" && body != test.body {
+			t.Errorf("%s: got %s, want %s", test.uri, body, test.body)
+		}
+	}
+
+	ctx := serveRequest(srv, "GET", "/countries", "")
+	var countries CountriesAvg
+	easyjson.Unmarshal(ctx.Response.Body(), &countries)
+	if len(countries.Countries) != 2 || countries.Countries[0].Country != "Италия" ||
+		countries.Countries[1].Country != "Россия" || countries.Countries[1].Visits != 6 {
+		t.Errorf("got %s", ctx.Response.Body())
+	}
+}
@@ -0,0 +1,95 @@
+package httpapi
+
+import (
+	"github.com/disc/highloadcup/model"
+	"github.com/mailru/easyjson"
+	"github.com/valyala/fasthttp"
+)
+
+const (
+	errorValidation         = "validation"
+	errorInvalidQuery       = "invalid_query"
+	errorBadRequest         = "bad_request"
+	errorNotFound           = "not_found"
+	errorPreconditionFailed = "precondition_failed"
+	errorGone               = "gone"
+	errorInternal           = "internal"
+)
+
+var errInvalidQuery = &QueryError{Message: "invalid query parameters"}
+
+//easyjson:json
+type ErrorResponse struct {
+	Error ErrorBody `json:"error"`
+}
+
+type ErrorBody struct {
+	Code    string             `json:"code"`
+	Field   string             `json:"field,omitempty"`
+	Message string             `json:"message"`
+	Fields  []model.FieldError `json:"fields,omitempty"`
+}
+
+// QueryError names the query parameter a request can't be served with.
+type QueryError struct {
+	Param   string
+	Message string
+}
+
+func (e *QueryError) Error() string {
+	if e.Param == "" {
+		return e.Message
+	}
+	return e.Param + " " + e.Message
+}
+
+// writeError answers with the error envelope, or with the bare {} body the
+// contest expects if BareErrors is set.
+func (srv *Server) writeError(ctx *fasthttp.RequestCtx, status int, body ErrorBody) {
+	if srv.BareErrors {
+		if status == 404 {
+			ctx.NotFound()
+		} else {
+			ctx.Error("{}", status)
+		}
+		return
+	}
+	response, _ := easyjson.Marshal(ErrorResponse{body})
+	ctx.SetStatusCode(status)
+	ctx.SetContentType("application/json")
+	ctx.SetBody(response)
+}
+
+// badRequest answers 400, naming the offending field or query parameter if
+// the error tells it.
+func (srv *Server) badRequest(ctx *fasthttp.RequestCtx, err error) {
+	switch err := err.(type) {
+	case *model.ValidationError:
+		first := err.Errors[0]
+		srv.writeError(ctx, 400, ErrorBody{errorValidation, first.Field, first.Message, err.Errors})
+	case *model.PatchError:
+		srv.writeError(ctx, 400, ErrorBody{Code: errorValidation, Field: err.Field, Message: err.Message})
+	case *QueryError:
+		srv.writeError(ctx, 400, ErrorBody{Code: errorInvalidQuery, Field: err.Param, Message: err.Message})
+	default:
+		srv.writeError(ctx, 400, ErrorBody{Code: errorBadRequest, Message: err.Error()})
+	}
+}
+
+// writeFailed answers 400 for an entity the store refused as invalid and 500
+// for one it could not write.
+func (srv *Server) writeFailed(ctx *fasthttp.RequestCtx, err error) {
+	if _, ok := err.(*model.ValidationError); ok {
+		srv.badRequest(ctx, err)
+		return
+	}
+	srv.writeError(ctx, 500, ErrorBody{Code: errorInternal, Message: err.Error()})
+}
+
+func (srv *Server) notFound(ctx *fasthttp.RequestCtx) {
+	srv.writeError(ctx, 404, ErrorBody{Code: errorNotFound, Message: "not found"})
+}
+
+func (srv *Server) writePreconditionFailed(ctx *fasthttp.RequestCtx) {
+	srv.writeError(ctx, 412, ErrorBody{Code: errorPreconditionFailed, Message: "the entity has changed"})
+}
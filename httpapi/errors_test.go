@@ -0,0 +1,107 @@
+package httpapi
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/disc/highloadcup/model"
+	"github.com/disc/highloadcup/store"
+)
+
+func TestErrorResponses(t *testing.T) {
+	t.Parallel()
+	srv := newTestServer()
+	srv.store.Users.Update(model.User{Id: 900000400, Email: "errors@example.com", First_name: "A", Last_name: "B", Gender: "m"})
+
+	tests := []struct {
+		method string
+		uri    string
+		body   string
+		status int
+		bare   string
+		full   string
+	}{
+		{"GET", "/users/900000400?asOf=x", "", 400, "{}",
+			`{"error":{"code":"invalid_query","field":"asOf","message":"must be a unix timestamp"}}`},
+		{"POST", "/users/900000400", `{"gender": "x"}`, 400, "{}",
+			`{"error":{"code":"validation","field":"gender","message":"must be m or f","fields":[{"field":"gender","message":"must be m or f"}]}}`},
+		{"GET", "/users/900000401", "", 404, "404 Page not found",
+			`{"error":{"code":"not_found","message":"not found"}}`},
+	}
+	for _, bare := range []bool{false, true} {
+		srv.BareErrors = bare
+		for _, test := range tests {
+			ctx := serveRequest(srv, test.method, test.uri, test.body)
+			expected := test.full
+			if bare {
+				expected = test.bare
+			}
+			if ctx.Response.StatusCode() != test.status || string(ctx.Response.Body()) != expected {
+				t.Errorf("%s %s: got %d %s, want %d %s", test.method, test.uri,
+					ctx.Response.StatusCode(), ctx.Response.Body(), test.status, expected)
+			}
+		}
+	}
+}
+
+// TestShortPaths requests paths shorter than the routes look at, which get
+// the not found envelope.
+func TestShortPaths(t *testing.T) {
+	t.Parallel()
+	srv := newTestServer()
+
+	for _, uri := range []string{"/", "/u", "/v", "/l", "/us", "/vis", "/loc", "/users/", "/visits", "/locations/"} {
+		for _, method := range []string{"GET", "POST"} {
+			ctx := serveRequest(srv, method, uri, "{}")
+			if body := string(ctx.Response.Body()); ctx.Response.StatusCode() != 404 || body != `{"error":{"code":"not_found","message":"not found"}}` {
+				t.Errorf("%s %s: got %d %s", method, uri, ctx.Response.StatusCode(), body)
+			}
+		}
+	}
+}
+
+// TestFailedWrites closes the files of a file store, so every write fails
+// and has to be answered with 500 instead of being reported as done.
+func TestFailedWrites(t *testing.T) {
+	t.Parallel()
+	dir, err := ioutil.TempDir("", "httpapi")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	s, err := store.OpenFileStore(dir, store.NewStore(16))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Users.Update(model.User{Id: 1, Email: "failed@example.com", First_name: "A", Last_name: "B", Gender: "m"})
+	s.Locations.Update(model.Location{Id: 1, Place: "Сад", Country: "Россия", City: "Москва"})
+	s.Visits.Update(model.Visit{Id: 1, Location: 1, User: 1, Visited_at: model.MinVisitedAt, Mark: 3})
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+	srv := NewServer(s, 16)
+
+	tests := []struct {
+		uri     string
+		body    string
+		ifMatch string
+	}{
+		{"/users/new", `{"id": 2, "email": "new@example.com", "first_name": "A", "last_name": "B", "gender": "f", "birth_date": 0}`, ""},
+		{"/locations/new", `{"id": 2, "place": "Пруд", "country": "Россия", "city": "Москва", "distance": 1}`, ""},
+		{"/locations/1", `{"place": "Пруд"}`, ""},
+		{"/locations/1", `{"place": "Пруд"}`, `"1"`},
+		{"/visits/new", `{"id": 2, "location": 1, "user": 1, "visited_at": 946684800, "mark": 4}`, ""},
+		{"/visits/1", `{"mark": 4}`, ""},
+		{"/visits/1", `{"mark": 4}`, `"1"`},
+	}
+	for _, test := range tests {
+		ctx := serveRequest(srv, "POST", test.uri, test.body, "If-Match", test.ifMatch)
+		if status := ctx.Response.StatusCode(); status != 500 {
+			t.Errorf("%s with If-Match %s: got %d %s", test.uri, test.ifMatch, status, ctx.Response.Body())
+		}
+	}
+	if s.Locations.Get(2) != nil || s.Locations.Get(1).Place != "Сад" || s.Visits.Get(2) != nil || s.Visits.Get(1).Mark != 3 {
+		t.Error("applied a failed write")
+	}
+}
@@ -0,0 +1,61 @@
+package httpapi
+
+import (
+	"bytes"
+	"strconv"
+
+	"github.com/valyala/fasthttp"
+)
+
+func entityTag(version uint) []byte {
+	return []byte(`"` + strconv.FormatUint(uint64(version), 10) + `"`)
+}
+
+func setEntityTag(ctx *fasthttp.RequestCtx, version uint) {
+	ctx.Response.Header.SetBytesV("ETag", entityTag(version))
+}
+
+// matchesEntityTag reports whether an If-Match/If-None-Match header value
+// lists the tag. With weak set, as for If-None-Match, weak tags compare equal
+// to their strong counterparts; If-Match needs the strong comparison, which
+// no weak tag passes (RFC 7232, section 2.3.2).
+func matchesEntityTag(header []byte, tag []byte, weak bool) bool {
+	for _, candidate := range bytes.Split(header, []byte(",")) {
+		candidate = bytes.TrimSpace(candidate)
+		if bytes.HasPrefix(candidate, []byte("W/")) {
+			if !weak {
+				continue
+			}
+			candidate = candidate[len("W/"):]
+		}
+		if bytes.Equal(candidate, []byte("*")) || bytes.Equal(candidate, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// notModified sets the ETag of an entity being read and answers 304 when the
+// client already holds that version.
+func notModified(ctx *fasthttp.RequestCtx, version uint) bool {
+	setEntityTag(ctx, version)
+
+	header := ctx.Request.Header.Peek("If-None-Match")
+	if len(header) > 0 && matchesEntityTag(header, entityTag(version), true) {
+		ctx.NotModified()
+		setEntityTag(ctx, version)
+		return true
+	}
+	return false
+}
+
+func hasIfMatch(ctx *fasthttp.RequestCtx) bool {
+	return len(ctx.Request.Header.Peek("If-Match")) > 0
+}
+
+// preconditionFailed reports whether an update carries an If-Match header
+// that does not list the current version of the entity.
+func preconditionFailed(ctx *fasthttp.RequestCtx, version uint) bool {
+	header := ctx.Request.Header.Peek("If-Match")
+	return len(header) > 0 && !matchesEntityTag(header, entityTag(version), false)
+}
@@ -0,0 +1,71 @@
+package httpapi
+
+import (
+	"testing"
+
+	"github.com/disc/highloadcup/model"
+)
+
+func TestMatchesEntityTag(t *testing.T) {
+	tests := []struct {
+		header string
+		weak   bool
+		match  bool
+	}{
+		{`"2"`, false, true},
+		{`"2"`, true, true},
+		{`"1", "2"`, false, true},
+		{`"1"`, true, false},
+		{`W/"2"`, true, true},
+		{`W/"2"`, false, false},
+		{`W/"1", "2"`, false, true},
+		{`*`, false, true},
+		{`2`, true, false},
+	}
+	for _, test := range tests {
+		if match := matchesEntityTag([]byte(test.header), entityTag(2), test.weak); match != test.match {
+			t.Errorf("%s (weak %v): got %v", test.header, test.weak, match)
+		}
+	}
+}
+
+func TestEntityTags(t *testing.T) {
+	const userId = 900000500
+	t.Parallel()
+	srv := newTestServer()
+	srv.store.Users.Update(model.User{Id: userId, Email: "etag@example.com", First_name: "A", Last_name: "B", Gender: "m"})
+
+	request := func(method string, body string, header string, value string) (int, string) {
+		ctx := serveRequest(srv, method, "/users/900000500", body, header, value)
+		return ctx.Response.StatusCode(), string(ctx.Response.Header.Peek("ETag"))
+	}
+
+	tests := []struct {
+		method string
+		header string
+		value  string
+		status int
+		etag   string
+	}{
+		{"GET", "", "", 200, `"1"`},
+		{"GET", "If-None-Match", `"1"`, 304, `"1"`},
+		{"GET", "If-None-Match", `W/"1"`, 304, `"1"`},
+		{"GET", "If-None-Match", `"2"`, 200, `"1"`},
+		{"POST", "If-Match", `"2"`, 412, ""},
+		{"POST", "If-Match", `W/"1"`, 412, ""},
+		{"POST", "If-Match", `"1"`, 200, `"2"`},
+		{"POST", "If-Match", `"1"`, 412, ""},
+		{"GET", "If-None-Match", `"1"`, 200, `"2"`},
+	}
+	for _, test := range tests {
+		body := ""
+		if test.method == "POST" {
+			body = `{"first_name": "C"}`
+		}
+		status, etag := request(test.method, body, test.header, test.value)
+		if status != test.status || etag != test.etag {
+			t.Errorf("%s with %s: %s: got %d and ETag %s, want %d and %s",
+				test.method, test.header, test.value, status, etag, test.status, test.etag)
+		}
+	}
+}
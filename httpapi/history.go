@@ -0,0 +1,26 @@
+package httpapi
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/valyala/fasthttp"
+)
+
+// revisionTime stamps a created or updated entity. Entities from the initial
+// data set keep a zero stamp and so are current for any asOf.
+func revisionTime() int {
+	return int(time.Now().Unix())
+}
+
+// parseAsOf reads the optional asOf unix timestamp from the query.
+func parseAsOf(args *fasthttp.Args) (*int, error) {
+	if !args.Has("asOf") {
+		return nil, nil
+	}
+	asOf, err := strconv.Atoi(string(args.Peek("asOf")))
+	if err != nil {
+		return nil, &QueryError{"asOf", "must be a unix timestamp"}
+	}
+	return &asOf, nil
+}
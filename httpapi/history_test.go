@@ -0,0 +1,63 @@
+package httpapi
+
+import (
+	"testing"
+
+	"github.com/disc/highloadcup/model"
+	"github.com/disc/highloadcup/store"
+)
+
+// newHistoryServer keeps two past revisions per entity. Visit 1 belongs to
+// user 20 from 100, moves to user 21 at 200 and is marked anew at 300 and 400.
+func newHistoryServer() *Server {
+	srv := NewServer(store.NewStore(2), 100)
+	srv.store.Users.Update(model.User{Id: 20, Email: "a@example.com", Gender: "m"})
+	srv.store.Users.Update(model.User{Id: 21, Email: "b@example.com", Gender: "f"})
+	srv.store.Locations.Update(model.Location{Id: 10, Place: "Парк", Country: "Россия"})
+	for _, visit := range []model.Visit{
+		{Id: 1, Location: 10, User: 20, Visited_at: 1000000000, Mark: 1, UpdatedAt: 100},
+		{Id: 1, Location: 10, User: 21, Visited_at: 1000000000, Mark: 2, UpdatedAt: 200},
+		{Id: 1, Location: 10, User: 21, Visited_at: 1000000000, Mark: 3, UpdatedAt: 300},
+		{Id: 1, Location: 10, User: 21, Visited_at: 1000000000, Mark: 4, UpdatedAt: 400},
+	} {
+		srv.store.Visits.Update(visit)
+	}
+	return srv
+}
+
+func TestVisitHistory(t *testing.T) {
+	t.Parallel()
+	srv := newHistoryServer()
+
+	tests := []struct {
+		uri    string
+		status int
+		body   string
+	}{
+		// The first revision has aged out of the two kept.
+		{"/visits/1/history", 200, `{"history":[` +
+			`{"visit":{"id":1,"location":10,"user":21,"visited_at":1000000000,"mark":2},"version":2,"updated_at":200},` +
+			`{"visit":{"id":1,"location":10,"user":21,"visited_at":1000000000,"mark":3},"version":3,"updated_at":300},` +
+			`{"visit":{"id":1,"location":10,"user":21,"visited_at":1000000000,"mark":4},"version":4,"updated_at":400}]}`},
+		{"/visits/2/history", 404, ""},
+		{"/visits/1", 200, `{"id":1,"location":10,"user":21,"visited_at":1000000000,"mark":4}`},
+		{"/visits/1?asOf=350", 200, `{"id":1,"location":10,"user":21,"visited_at":1000000000,"mark":3}`},
+		{"/visits/1?asOf=200", 200, `{"id":1,"location":10,"user":21,"visited_at":1000000000,"mark":2}`},
+		{"/visits/1?asOf=150", 404, ""},
+		{"/visits/1?asOf=x", 400, ""},
+		{"/users/21/visits", 200, `{"visits":[{"mark":4,"visited_at":1000000000,"place":"Парк"}]}`},
+		{"/users/21/visits?asOf=250", 200, `{"visits":[{"mark":2,"visited_at":1000000000,"place":"Парк"}]}`},
+		{"/users/20/visits", 200, `{"visits":[]}`},
+		{"/users/20/visits?asOf=250", 200, `{"visits":[]}`},
+	}
+	for _, test := range tests {
+		ctx := serveRequest(srv, "GET", test.uri, "")
+		if status := ctx.Response.StatusCode(); status != test.status {
+			t.Errorf("%s: got status %d, want %d", test.uri, status, test.status)
+			continue
+		}
+		if body := string(ctx.Response.Body()); test.body != "" && body != test.body {
+			t.Errorf("%s: got %s, want %s", test.uri, body, test.body)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package httpapi
+
+import (
+	"bytes"
+	"strconv"
+
+	"github.com/disc/highloadcup/query"
+	"github.com/mailru/easyjson"
+	"github.com/valyala/fasthttp"
+)
+
+//easyjson:json
+type LocationAvg struct {
+	Avg float64 `json:"avg"`
+}
+
+func (srv *Server) locationAvgRequestHandler(ctx *fasthttp.RequestCtx, locationId uint, args *fasthttp.Args) {
+	if location := srv.store.Locations.Get(locationId); location == nil {
+		srv.notFound(ctx)
+		return
+	}
+
+	filters, ok := parseLocationAvgFilter(args)
+	if !ok {
+		srv.badRequest(ctx, errInvalidQuery)
+		return
+	}
+
+	response, _ := easyjson.Marshal(LocationAvg{query.Round(query.GetLocationAvg(srv.store, locationId, filters), .5, 5)})
+	ctx.Success("application/json", response)
+}
+
+func parseLocationAvgFilter(args *fasthttp.Args) (query.LocationAvgFilter, bool) {
+	var filters = query.LocationAvgFilter{}
+	if fromDate := args.Has("fromDate"); fromDate {
+		if fromDateInt, err := strconv.Atoi(string(args.Peek("fromDate"))); err != nil {
+			return filters, false
+		} else {
+			filters.FromDate = &fromDateInt
+		}
+	}
+	if toDate := args.Has("toDate"); toDate {
+		if toDateInt, err := strconv.Atoi(string(args.Peek("toDate"))); err != nil {
+			return filters, false
+		} else {
+			filters.ToDate = &toDateInt
+		}
+	}
+	if fromAge := args.Has("fromAge"); fromAge {
+		if fromAgeInt, err := strconv.Atoi(string(args.Peek("fromAge"))); err != nil {
+			return filters, false
+		} else {
+			filters.FromAge = &fromAgeInt
+		}
+	}
+	if toAge := args.Has("toAge"); toAge {
+		if toAgeInt, err := strconv.Atoi(string(args.Peek("toAge"))); err != nil {
+			return filters, false
+		} else {
+			filters.ToAge = &toAgeInt
+		}
+	}
+	if gender := args.Has("gender"); gender {
+		if genderStr := args.Peek("gender"); len(genderStr) > 0 && bytes.ContainsAny(genderStr, "mf") {
+			filters.Gender = &genderStr
+		} else {
+			return filters, false
+		}
+	}
+
+	return filters, true
+}
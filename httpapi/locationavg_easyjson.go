@@ -0,0 +1,84 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package httpapi
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson44e6a331DecodeGithubComDiscHighloadcupHttpapi(in *jlexer.Lexer, out *LocationAvg) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "avg":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Avg = float64(in.Float64())
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson44e6a331EncodeGithubComDiscHighloadcupHttpapi(out *jwriter.Writer, in LocationAvg) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"avg\":"
+		out.RawString(prefix[1:])
+		out.Float64(float64(in.Avg))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v LocationAvg) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson44e6a331EncodeGithubComDiscHighloadcupHttpapi(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v LocationAvg) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson44e6a331EncodeGithubComDiscHighloadcupHttpapi(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *LocationAvg) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson44e6a331DecodeGithubComDiscHighloadcupHttpapi(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *LocationAvg) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson44e6a331DecodeGithubComDiscHighloadcupHttpapi(l, v)
+}
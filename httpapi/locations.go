@@ -0,0 +1,119 @@
+package httpapi
+
+import (
+	"errors"
+
+	"github.com/disc/highloadcup/model"
+	"github.com/disc/highloadcup/store"
+	"github.com/mailru/easyjson"
+	"github.com/valyala/fasthttp"
+)
+
+func (srv *Server) getLocationRequestHandler(ctx *fasthttp.RequestCtx, entityId uint) {
+	asOf, err := parseAsOf(ctx.QueryArgs())
+	if err != nil {
+		srv.badRequest(ctx, err)
+		return
+	}
+
+	location := srv.store.Locations.Get(entityId)
+	if asOf != nil {
+		location = srv.store.Locations.GetAsOf(entityId, *asOf)
+	}
+	if location != nil {
+		if notModified(ctx, location.Version) {
+			return
+		}
+		response, _ := easyjson.Marshal(location)
+		ctx.Success("application/json", response)
+		return
+	}
+	srv.notFound(ctx)
+}
+
+func (srv *Server) createLocationRequestHandler(ctx *fasthttp.RequestCtx) {
+	location, err := createLocation(srv.store.Locations, ctx.PostBody())
+	if err != nil {
+		srv.badRequest(ctx, err)
+		return
+	}
+	if version, _ := srv.store.Locations.Update(*location); version == 0 {
+		srv.writeFailed(ctx, store.ErrWriteFailed)
+		return
+	}
+	srv.writeSuccessResponse(ctx)
+}
+
+func (srv *Server) updateLocationRequestHandler(ctx *fasthttp.RequestCtx, entityId uint) {
+	if location := srv.store.Locations.Get(entityId); location != nil {
+		if preconditionFailed(ctx, location.Version) {
+			srv.writePreconditionFailed(ctx)
+			return
+		}
+		updatedLocation, err := updateLocation(ctx.PostBody(), location)
+		if err != nil {
+			srv.badRequest(ctx, err)
+			return
+		}
+		if hasIfMatch(ctx) {
+			if _, err := srv.store.Locations.UpdateIfVersion(*updatedLocation, location.Version); err == store.ErrVersionMismatch {
+				srv.writePreconditionFailed(ctx)
+				return
+			} else if err != nil {
+				srv.writeFailed(ctx, err)
+				return
+			}
+			setEntityTag(ctx, location.Version+1)
+		} else if version, _ := srv.store.Locations.Update(*updatedLocation); version == 0 {
+			srv.writeFailed(ctx, store.ErrWriteFailed)
+			return
+		}
+		srv.writeSuccessResponse(ctx)
+		return
+	}
+	srv.notFound(ctx)
+}
+
+func createLocation(locations store.LocationRepository, postBody []byte) (*model.Location, error) {
+	location := model.Location{}
+	if err := easyjson.Unmarshal(postBody, &location); err != nil {
+		return nil, err
+	}
+	if err := model.ValidateLocation(&location); err != nil {
+		return nil, err
+	}
+	if location := locations.Get(location.Id); location != nil {
+		return nil, errors.New("Location already exists")
+	}
+	location.UpdatedAt = revisionTime()
+
+	return &location, nil
+}
+
+func updateLocation(postBody []byte, location *model.Location) (*model.Location, error) {
+	var patch model.LocationPatch
+	if err := easyjson.Unmarshal(postBody, &patch); err != nil {
+		return nil, err
+	}
+
+	updatedLocation := *location
+	updatedLocation.UpdatedAt = revisionTime()
+
+	if patch.Place != nil {
+		updatedLocation.Place = *patch.Place
+	}
+	if patch.Country != nil {
+		updatedLocation.Country = *patch.Country
+	}
+	if patch.City != nil {
+		updatedLocation.City = *patch.City
+	}
+	if patch.Distance != nil {
+		updatedLocation.Distance = *patch.Distance
+	}
+	if err := model.ValidateLocation(&updatedLocation); err != nil {
+		return nil, err
+	}
+
+	return &updatedLocation, nil
+}
@@ -0,0 +1,76 @@
+package httpapi
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/disc/highloadcup/model"
+	"github.com/disc/highloadcup/query"
+	"github.com/disc/highloadcup/store"
+	"github.com/mailru/easyjson"
+	"github.com/valyala/fasthttp"
+)
+
+//easyjson:json
+type LocationsPage struct {
+	Locations []model.Location `json:"locations"`
+	Total     int              `json:"total"`
+}
+
+func parseLocationSearchFilter(args *fasthttp.Args) (store.LocationSearchFilter, bool) {
+	var filter = store.LocationSearchFilter{Sort: "id"}
+
+	if args.Has("country") {
+		country := string(args.Peek("country"))
+		filter.Country = &country
+	}
+	if args.Has("city") {
+		city := string(args.Peek("city"))
+		filter.City = &city
+	}
+	if args.Has("place") {
+		place := strings.ToLower(string(args.Peek("place")))
+		filter.Place = &place
+	}
+	if args.Has("fromDistance") {
+		fromDistance, err := strconv.ParseUint(string(args.Peek("fromDistance")), 10, 32)
+		if err != nil {
+			return filter, false
+		}
+		distance := uint(fromDistance)
+		filter.FromDistance = &distance
+	}
+	if args.Has("toDistance") {
+		toDistance, err := strconv.ParseUint(string(args.Peek("toDistance")), 10, 32)
+		if err != nil {
+			return filter, false
+		}
+		distance := uint(toDistance)
+		filter.ToDistance = &distance
+	}
+	if args.Has("sort") {
+		switch filter.Sort = string(args.Peek("sort")); filter.Sort {
+		case "id", "distance", "avg":
+		default:
+			return filter, false
+		}
+	}
+
+	var ok bool
+	filter.Page, ok = parsePage(args)
+	return filter, ok
+}
+
+func (srv *Server) locationsRequestHandler(ctx *fasthttp.RequestCtx, args *fasthttp.Args) {
+	filter, ok := parseLocationSearchFilter(args)
+	if !ok {
+		srv.badRequest(ctx, errInvalidQuery)
+		return
+	}
+
+	locations, total := srv.store.Locations.Search(filter, func(id uint) float64 {
+		return query.GetLocationAvg(srv.store, id, query.LocationAvgFilter{})
+	})
+	response, _ := easyjson.Marshal(LocationsPage{locations, total})
+	ctx.Success("application/json", response)
+}
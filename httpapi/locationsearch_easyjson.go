@@ -0,0 +1,128 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package httpapi
+
+import (
+	json "encoding/json"
+	model "github.com/disc/highloadcup/model"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson53e7b0f5DecodeGithubComDiscHighloadcupHttpapi(in *jlexer.Lexer, out *LocationsPage) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "locations":
+			if in.IsNull() {
+				in.Skip()
+				out.Locations = nil
+			} else {
+				in.Delim('[')
+				if out.Locations == nil {
+					if !in.IsDelim(']') {
+						out.Locations = make([]model.Location, 0, 0)
+					} else {
+						out.Locations = []model.Location{}
+					}
+				} else {
+					out.Locations = (out.Locations)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 model.Location
+					if in.IsNull() {
+						in.Skip()
+					} else {
+						(v1).UnmarshalEasyJSON(in)
+					}
+					out.Locations = append(out.Locations, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "total":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Total = int(in.Int())
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson53e7b0f5EncodeGithubComDiscHighloadcupHttpapi(out *jwriter.Writer, in LocationsPage) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"locations\":"
+		out.RawString(prefix[1:])
+		if in.Locations == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v2, v3 := range in.Locations {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				(v3).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"total\":"
+		out.RawString(prefix)
+		out.Int(int(in.Total))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v LocationsPage) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson53e7b0f5EncodeGithubComDiscHighloadcupHttpapi(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v LocationsPage) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson53e7b0f5EncodeGithubComDiscHighloadcupHttpapi(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *LocationsPage) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson53e7b0f5DecodeGithubComDiscHighloadcupHttpapi(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *LocationsPage) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson53e7b0f5DecodeGithubComDiscHighloadcupHttpapi(l, v)
+}
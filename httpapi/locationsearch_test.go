@@ -0,0 +1,93 @@
+package httpapi
+
+import (
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/disc/highloadcup/model"
+	"github.com/mailru/easyjson"
+)
+
+// newLocationsServer adds five locations and seven visits to the users of
+// newUserSearchServer. The average marks are 4, 2, 4, 1 and 0.
+func newLocationsServer() *Server {
+	srv := newUserSearchServer()
+	for _, location := range []model.Location{
+		{Id: 10, Place: "Старый парк", Country: "Россия", City: "Москва", Distance: 10},
+		{Id: 11, Place: "Новый пруд", Country: "Россия", City: "Тверь", Distance: 30},
+		{Id: 12, Place: "Парк Горького", Country: "Россия", City: "Москва", Distance: 20},
+		{Id: 13, Place: "Фонтан", Country: "Италия", City: "Рим", Distance: 5},
+		{Id: 14, Place: "Музей", Country: "Италия", City: "Рим", Distance: 50},
+	} {
+		srv.store.Locations.Update(location)
+	}
+	for _, visit := range []model.Visit{
+		{Id: 1, Location: 10, User: 1, Visited_at: 1000, Mark: 5},
+		{Id: 2, Location: 10, User: 2, Visited_at: 2000, Mark: 3},
+		{Id: 3, Location: 11, User: 1, Visited_at: 3000, Mark: 2},
+		{Id: 4, Location: 12, User: 3, Visited_at: 4000, Mark: 5},
+		{Id: 5, Location: 12, User: 2, Visited_at: 5000, Mark: 4},
+		{Id: 6, Location: 12, User: 1, Visited_at: 6000, Mark: 3},
+		{Id: 7, Location: 13, User: 3, Visited_at: 7000, Mark: 1},
+	} {
+		srv.store.Visits.Update(visit)
+	}
+	return srv
+}
+
+func searchLocations(srv *Server, uri string) (string, int, int) {
+	ctx := serveRequest(srv, "GET", uri, "")
+	if status := ctx.Response.StatusCode(); status != 200 {
+		return "", 0, status
+	}
+	var page LocationsPage
+	easyjson.Unmarshal(ctx.Response.Body(), &page)
+	ids := make([]uint, 0, len(page.Locations))
+	for _, location := range page.Locations {
+		ids = append(ids, location.Id)
+	}
+	return fmt.Sprint(ids), page.Total, 200
+}
+
+func TestLocationSearch(t *testing.T) {
+	t.Parallel()
+	srv := newLocationsServer()
+
+	tests := []struct {
+		uri    string
+		status int
+		ids    string
+		total  int
+	}{
+		{"/locations", 200, "[10 11 12 13 14]", 5},
+		{"/locations?country=" + url.QueryEscape("Италия"), 200, "[13 14]", 2},
+		{"/locations?city=" + url.QueryEscape("Москва"), 200, "[10 12]", 2},
+		{"/locations?country=" + url.QueryEscape("Россия") + "&city=" + url.QueryEscape("Рим"), 200, "[]", 0},
+		{"/locations?place=" + url.QueryEscape("ПАРК"), 200, "[10 12]", 2},
+		{"/locations?fromDistance=10&toDistance=30", 200, "[10 11 12]", 3},
+		{"/locations?sort=distance", 200, "[13 10 12 11 14]", 5},
+		{"/locations?sort=avg", 200, "[14 13 11 10 12]", 5},
+		{"/locations?sort=avg&order=desc&limit=2", 200, "[12 10]", 5},
+		{"/locations?sort=avg&offset=1&limit=2", 200, "[13 11]", 5},
+		{"/locations?sort=avg&country=" + url.QueryEscape("Россия"), 200, "[11 10 12]", 3},
+		{"/locations?offset=5", 200, "[]", 5},
+		{"/locations?fromDistance=-1", 400, "", 0},
+		{"/locations?toDistance=x", 400, "", 0},
+		{"/locations?sort=place", 400, "", 0},
+		{"/locations?limit=0", 400, "", 0},
+		{"/locations?order=up", 400, "", 0},
+	}
+	for _, test := range tests {
+		ids, total, status := searchLocations(srv, test.uri)
+		if status != test.status || ids != test.ids || total != test.total {
+			t.Errorf("%s: got %d %s of %d, want %d %s of %d", test.uri, status, ids, total, test.status, test.ids, test.total)
+		}
+	}
+
+	// Moving the only visit of 13 to 14 with a new mark reorders both.
+	srv.store.Visits.Update(model.Visit{Id: 7, Location: 14, User: 3, Visited_at: 7000, Mark: 5})
+	if ids, _, _ := searchLocations(srv, "/locations?sort=avg"); ids != "[13 11 10 12 14]" {
+		t.Errorf("got %s sorted by avg after a visit moved", ids)
+	}
+}
@@ -0,0 +1,24 @@
+package httpapi
+
+import (
+	"github.com/disc/highloadcup/store"
+	"github.com/mailru/easyjson"
+	"github.com/valyala/fasthttp"
+)
+
+func (srv *Server) locationsTextSearchRequestHandler(ctx *fasthttp.RequestCtx, args *fasthttp.Args) {
+	q := string(args.Peek("q"))
+	if len(store.Tokenize(q)) == 0 {
+		srv.badRequest(ctx, &QueryError{"q", "must have a word to search for"})
+		return
+	}
+	page, ok := parsePage(args)
+	if !ok {
+		srv.badRequest(ctx, errInvalidQuery)
+		return
+	}
+
+	locations, total := srv.store.Locations.TextSearch(q, page)
+	response, _ := easyjson.Marshal(LocationsPage{locations, total})
+	ctx.Success("application/json", response)
+}
@@ -0,0 +1,37 @@
+package httpapi
+
+import (
+	"github.com/disc/highloadcup/query"
+	"github.com/mailru/easyjson"
+	"github.com/valyala/fasthttp"
+)
+
+//easyjson:json
+type LocationTimeline struct {
+	Timeline []query.TimelineBucket `json:"timeline"`
+}
+
+func (srv *Server) locationTimelineRequestHandler(ctx *fasthttp.RequestCtx, locationId uint, args *fasthttp.Args) {
+	if location := srv.store.Locations.Get(locationId); location == nil {
+		srv.notFound(ctx)
+		return
+	}
+
+	bucket := "month"
+	if args.Has("bucket") {
+		switch bucket = string(args.Peek("bucket")); bucket {
+		case "day", "week", "month", "year":
+		default:
+			srv.badRequest(ctx, &QueryError{"bucket", "must be day, week, month or year"})
+			return
+		}
+	}
+	filters, ok := parseLocationAvgFilter(args)
+	if !ok {
+		srv.badRequest(ctx, errInvalidQuery)
+		return
+	}
+
+	response, _ := easyjson.Marshal(LocationTimeline{query.GetLocationTimeline(srv.store, locationId, bucket, filters)})
+	ctx.Success("application/json", response)
+}
@@ -0,0 +1,117 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package httpapi
+
+import (
+	json "encoding/json"
+	query "github.com/disc/highloadcup/query"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson6c2dd974DecodeGithubComDiscHighloadcupHttpapi(in *jlexer.Lexer, out *LocationTimeline) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "timeline":
+			if in.IsNull() {
+				in.Skip()
+				out.Timeline = nil
+			} else {
+				in.Delim('[')
+				if out.Timeline == nil {
+					if !in.IsDelim(']') {
+						out.Timeline = make([]query.TimelineBucket, 0, 2)
+					} else {
+						out.Timeline = []query.TimelineBucket{}
+					}
+				} else {
+					out.Timeline = (out.Timeline)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 query.TimelineBucket
+					if in.IsNull() {
+						in.Skip()
+					} else {
+						(v1).UnmarshalEasyJSON(in)
+					}
+					out.Timeline = append(out.Timeline, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6c2dd974EncodeGithubComDiscHighloadcupHttpapi(out *jwriter.Writer, in LocationTimeline) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"timeline\":"
+		out.RawString(prefix[1:])
+		if in.Timeline == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v2, v3 := range in.Timeline {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				(v3).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v LocationTimeline) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6c2dd974EncodeGithubComDiscHighloadcupHttpapi(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v LocationTimeline) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6c2dd974EncodeGithubComDiscHighloadcupHttpapi(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *LocationTimeline) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6c2dd974DecodeGithubComDiscHighloadcupHttpapi(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *LocationTimeline) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6c2dd974DecodeGithubComDiscHighloadcupHttpapi(l, v)
+}
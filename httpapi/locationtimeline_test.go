@@ -0,0 +1,44 @@
+package httpapi
+
+import (
+	"testing"
+
+	"github.com/disc/highloadcup/model"
+)
+
+func TestLocationTimeline(t *testing.T) {
+	const day = 24 * 60 * 60
+	t.Parallel()
+	srv := newLocationsServer()
+	// Besides its three visits on 1 January 1970, 12 is visited on Tuesday
+	// 10 February 1970 and on Friday 5 February 1971.
+	srv.store.Visits.Update(model.Visit{Id: 8, Location: 12, User: 2, Visited_at: 40 * day, Mark: 1})
+	srv.store.Visits.Update(model.Visit{Id: 9, Location: 12, User: 3, Visited_at: 400 * day, Mark: 2})
+
+	tests := []struct {
+		uri    string
+		status int
+		body   string
+	}{
+		{"/locations/12/timeline", 200, `{"timeline":[{"from":0,"count":3,"avg":4},{"from":2678400,"count":1,"avg":1},{"from":34214400,"count":1,"avg":2}]}`},
+		{"/locations/12/timeline?bucket=day", 200, `{"timeline":[{"from":0,"count":3,"avg":4},{"from":3456000,"count":1,"avg":1},{"from":34560000,"count":1,"avg":2}]}`},
+		{"/locations/12/timeline?bucket=week", 200, `{"timeline":[{"from":-259200,"count":3,"avg":4},{"from":3369600,"count":1,"avg":1},{"from":34214400,"count":1,"avg":2}]}`},
+		{"/locations/12/timeline?bucket=year", 200, `{"timeline":[{"from":0,"count":4,"avg":3.25},{"from":31536000,"count":1,"avg":2}]}`},
+		{"/locations/12/timeline?gender=m", 200, `{"timeline":[{"from":0,"count":2,"avg":4.5},{"from":2678400,"count":1,"avg":1},{"from":34214400,"count":1,"avg":2}]}`},
+		{"/locations/12/timeline?bucket=year&toDate=3000000", 200, `{"timeline":[{"from":0,"count":3,"avg":4}]}`},
+		{"/locations/14/timeline", 200, `{"timeline":[]}`},
+		{"/locations/99/timeline", 404, ""},
+		{"/locations/12/timeline?bucket=hour", 400, ""},
+		{"/locations/12/timeline?fromAge=x", 400, ""},
+	}
+	for _, test := range tests {
+		ctx := serveRequest(srv, "GET", test.uri, "")
+		if status := ctx.Response.StatusCode(); status != test.status {
+			t.Errorf("%s: got status %d, want %d", test.uri, status, test.status)
+			continue
+		}
+		if body := string(ctx.Response.Body()); test.body != "" && body != test.body {
+			t.Errorf("%s: got %s, want %s", test.uri, body, test.body)
+		}
+	}
+}
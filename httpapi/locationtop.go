@@ -0,0 +1,68 @@
+package httpapi
+
+import (
+	"strconv"
+
+	"github.com/disc/highloadcup/query"
+	"github.com/mailru/easyjson"
+	"github.com/valyala/fasthttp"
+)
+
+const (
+	topDefaultLimit = 10
+	topDefaultPrior = 10
+)
+
+//easyjson:json
+type TopLocations struct {
+	Locations []query.TopLocation `json:"locations"`
+}
+
+func parseLocationTopFilter(args *fasthttp.Args) (query.LocationTopFilter, bool) {
+	var (
+		filter = query.LocationTopFilter{Limit: topDefaultLimit, MinVisits: 1, Prior: topDefaultPrior}
+		ok     bool
+	)
+	if filter.Visits, ok = parseLocationAvgFilter(args); !ok {
+		return filter, false
+	}
+	if args.Has("country") {
+		country := string(args.Peek("country"))
+		filter.Country = &country
+	}
+	if args.Has("limit") {
+		limit, err := strconv.Atoi(string(args.Peek("limit")))
+		if err != nil || limit < 1 {
+			return filter, false
+		}
+		if filter.Limit = limit; filter.Limit > pageMaxLimit {
+			filter.Limit = pageMaxLimit
+		}
+	}
+	if args.Has("minVisits") {
+		minVisits, err := strconv.ParseUint(string(args.Peek("minVisits")), 10, 32)
+		if err != nil {
+			return filter, false
+		}
+		filter.MinVisits = uint(minVisits)
+	}
+	if args.Has("prior") {
+		prior, err := strconv.ParseFloat(string(args.Peek("prior")), 64)
+		if err != nil || prior < 0 {
+			return filter, false
+		}
+		filter.Prior = prior
+	}
+	return filter, true
+}
+
+func (srv *Server) topLocationsRequestHandler(ctx *fasthttp.RequestCtx, args *fasthttp.Args) {
+	filter, ok := parseLocationTopFilter(args)
+	if !ok {
+		srv.badRequest(ctx, errInvalidQuery)
+		return
+	}
+
+	response, _ := easyjson.Marshal(TopLocations{query.GetTopLocations(srv.store, filter)})
+	ctx.Success("application/json", response)
+}
@@ -0,0 +1,61 @@
+package httpapi
+
+import (
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/mailru/easyjson"
+)
+
+func TestTopLocations(t *testing.T) {
+	t.Parallel()
+	srv := newLocationsServer()
+
+	tests := []struct {
+		uri    string
+		status int
+		ids    string
+	}{
+		// With the prior of 10 visits at the mean mark of 23/7, the three
+		// visits of 12 outweigh the two of 10 at the same average.
+		{"/locations/top", 200, "[12 10 11 13]"},
+		{"/locations/top?prior=0", 200, "[12 10 11 13]"},
+		{"/locations/top?minVisits=2", 200, "[12 10]"},
+		{"/locations/top?limit=2", 200, "[12 10]"},
+		{"/locations/top?country=" + url.QueryEscape("Италия"), 200, "[13]"},
+		{"/locations/top?country=" + url.QueryEscape("Франция"), 200, "[]"},
+		{"/locations/top?gender=f&prior=0", 200, "[10 12 11]"},
+		{"/locations/top?fromDate=3000&toDate=6000&prior=0", 200, "[12 11]"},
+		{"/locations/top?limit=0", 400, ""},
+		{"/locations/top?minVisits=-1", 400, ""},
+		{"/locations/top?prior=-1", 400, ""},
+		{"/locations/top?prior=x", 400, ""},
+		{"/locations/top?gender=x", 400, ""},
+	}
+	for _, test := range tests {
+		ctx := serveRequest(srv, "GET", test.uri, "")
+		if status := ctx.Response.StatusCode(); status != test.status {
+			t.Errorf("%s: got status %d, want %d", test.uri, status, test.status)
+			continue
+		}
+		if test.status != 200 {
+			continue
+		}
+		var top TopLocations
+		easyjson.Unmarshal(ctx.Response.Body(), &top)
+		ids := make([]uint, 0, len(top.Locations))
+		for _, location := range top.Locations {
+			ids = append(ids, location.Id)
+		}
+		if fmt.Sprint(ids) != test.ids {
+			t.Errorf("%s: got %v, want %s", test.uri, ids, test.ids)
+		}
+	}
+
+	ctx := serveRequest(srv, "GET", "/locations/top?limit=1", "")
+	want := `{"locations":[{"id":12,"place":"Парк Горького","country":"Россия","city":"Москва","distance":20,"avg":4,"score":3.45055,"visits":3}]}`
+	if body := string(ctx.Response.Body()); body != want {
+		t.Errorf("got %s, want %s", body, want)
+	}
+}
@@ -0,0 +1,28 @@
+package httpapi
+
+import (
+	"github.com/disc/highloadcup/query"
+	"github.com/mailru/easyjson"
+	"github.com/valyala/fasthttp"
+)
+
+//easyjson:json
+type LocationVisits struct {
+	Visits []query.LocationVisit `json:"visits"`
+}
+
+func (srv *Server) locationVisitsRequestHandler(ctx *fasthttp.RequestCtx, locationId uint, args *fasthttp.Args) {
+	if location := srv.store.Locations.Get(locationId); location == nil {
+		srv.notFound(ctx)
+		return
+	}
+
+	filters, ok := parseLocationAvgFilter(args)
+	if !ok {
+		srv.badRequest(ctx, errInvalidQuery)
+		return
+	}
+
+	response, _ := easyjson.Marshal(LocationVisits{query.GetLocationVisits(srv.store, locationId, filters)})
+	ctx.Success("application/json", response)
+}
@@ -0,0 +1,117 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package httpapi
+
+import (
+	json "encoding/json"
+	query "github.com/disc/highloadcup/query"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson8eab70c1DecodeGithubComDiscHighloadcupHttpapi(in *jlexer.Lexer, out *LocationVisits) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "visits":
+			if in.IsNull() {
+				in.Skip()
+				out.Visits = nil
+			} else {
+				in.Delim('[')
+				if out.Visits == nil {
+					if !in.IsDelim(']') {
+						out.Visits = make([]query.LocationVisit, 0, 1)
+					} else {
+						out.Visits = []query.LocationVisit{}
+					}
+				} else {
+					out.Visits = (out.Visits)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 query.LocationVisit
+					if in.IsNull() {
+						in.Skip()
+					} else {
+						(v1).UnmarshalEasyJSON(in)
+					}
+					out.Visits = append(out.Visits, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson8eab70c1EncodeGithubComDiscHighloadcupHttpapi(out *jwriter.Writer, in LocationVisits) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"visits\":"
+		out.RawString(prefix[1:])
+		if in.Visits == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v2, v3 := range in.Visits {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				(v3).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v LocationVisits) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson8eab70c1EncodeGithubComDiscHighloadcupHttpapi(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v LocationVisits) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson8eab70c1EncodeGithubComDiscHighloadcupHttpapi(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *LocationVisits) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson8eab70c1DecodeGithubComDiscHighloadcupHttpapi(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *LocationVisits) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson8eab70c1DecodeGithubComDiscHighloadcupHttpapi(l, v)
+}
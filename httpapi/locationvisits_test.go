@@ -0,0 +1,47 @@
+package httpapi
+
+import (
+	"testing"
+
+	"github.com/disc/highloadcup/model"
+)
+
+func TestLocationVisits(t *testing.T) {
+	t.Parallel()
+	srv := newLocationsServer()
+	// A visit of a user that does not exist is left out.
+	srv.store.Visits.Update(model.Visit{Id: 20, Location: 12, User: 99, Visited_at: 4500, Mark: 1})
+
+	tests := []struct {
+		uri    string
+		status int
+		body   string
+	}{
+		{"/locations/12/visits", 200, `{"visits":[` +
+			`{"id":4,"user":3,"mark":5,"visited_at":4000,"gender":"m","age":25},` +
+			`{"id":5,"user":2,"mark":4,"visited_at":5000,"gender":"m","age":30},` +
+			`{"id":6,"user":1,"mark":3,"visited_at":6000,"gender":"f","age":20}]}`},
+		{"/locations/12/visits?gender=f", 200, `{"visits":[{"id":6,"user":1,"mark":3,"visited_at":6000,"gender":"f","age":20}]}`},
+		{"/locations/12/visits?fromAge=25", 200, `{"visits":[` +
+			`{"id":4,"user":3,"mark":5,"visited_at":4000,"gender":"m","age":25},` +
+			`{"id":5,"user":2,"mark":4,"visited_at":5000,"gender":"m","age":30}]}`},
+		{"/locations/12/visits?toAge=25", 200, `{"visits":[{"id":6,"user":1,"mark":3,"visited_at":6000,"gender":"f","age":20}]}`},
+		{"/locations/12/visits?fromDate=4500&toDate=5500", 200, `{"visits":[{"id":5,"user":2,"mark":4,"visited_at":5000,"gender":"m","age":30}]}`},
+		{"/locations/14/visits", 200, `{"visits":[]}`},
+		{"/locations/99/visits", 404, ""},
+		{"/locations/99/visits?gender=x", 404, ""},
+		{"/locations/12/visits?gender=x", 400, ""},
+		{"/locations/12/visits?fromDate=x", 400, ""},
+		{"/locations/12/visits?toAge=x", 400, ""},
+	}
+	for _, test := range tests {
+		ctx := serveRequest(srv, "GET", test.uri, "")
+		if status := ctx.Response.StatusCode(); status != test.status {
+			t.Errorf("%s: got status %d, want %d", test.uri, status, test.status)
+			continue
+		}
+		if body := string(ctx.Response.Body()); test.body != "" && body != test.body {
+			t.Errorf("%s: got %s, want %s", test.uri, body, test.body)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package httpapi
+
+import (
+	"net"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/disc/highloadcup/loader"
+	"github.com/disc/highloadcup/model"
+	"github.com/disc/highloadcup/store"
+	"github.com/valyala/fasthttp"
+)
+
+var (
+	loadDataOnce sync.Once
+	loadedStore  *store.Store
+)
+
+// loadData parses the unzipped data.zip from data/, as the server does on
+// start, into a store shared by the benchmarks.
+func loadData() *store.Store {
+	loadDataOnce.Do(func() {
+		loadedStore = store.NewStore(16)
+		if _, err := loader.ParseDataDir(loadedStore, "../data/"); err != nil {
+			panic(err)
+		}
+	})
+	return loadedStore
+}
+
+// newTestServer serves a fresh, empty store.
+func newTestServer() *Server {
+	return NewServer(store.NewStore(16), 1000)
+}
+
+func benchmarkVisitUpdates(b *testing.B, closeConnection bool) {
+	const visitId = 900000000
+	srv := newTestServer()
+	srv.store.Visits.Update(model.Visit{Id: visitId, Location: 1, User: 1, Visited_at: 1000000000, Mark: 3})
+	srv.CloseAfterWrite = closeConnection
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer ln.Close()
+	go fasthttp.Serve(ln, srv.HandleRequest)
+
+	client := &fasthttp.Client{MaxConnsPerHost: 1024}
+	uri := "http://" + ln.Addr().String() + "/visits/" + strconv.Itoa(visitId)
+	body := []byte(`{"mark": 4}`)
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		req := fasthttp.AcquireRequest()
+		resp := fasthttp.AcquireResponse()
+		defer fasthttp.ReleaseRequest(req)
+		defer fasthttp.ReleaseResponse(resp)
+
+		for pb.Next() {
+			req.SetRequestURI(uri)
+			req.Header.SetMethod("POST")
+			req.SetBody(body)
+			if err := client.Do(req, resp); err != nil {
+				b.Error(err)
+				return
+			}
+			if resp.StatusCode() != fasthttp.StatusOK {
+				b.Errorf("unexpected status %d", resp.StatusCode())
+				return
+			}
+		}
+	})
+}
+
+func BenchmarkVisitUpdatesKeepAlive(b *testing.B) {
+	benchmarkVisitUpdates(b, false)
+}
+
+func BenchmarkVisitUpdatesCloseAfterWrite(b *testing.B) {
+	benchmarkVisitUpdates(b, true)
+}
+
+// benchmarkRequest runs the request through HandleRequest without a network
+// round trip and reports the allocations made per request.
+func benchmarkRequest(b *testing.B, method string, uri string, body string) {
+	srv := NewServer(loadData(), 1000)
+
+	var ctx fasthttp.RequestCtx
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		ctx.Request.Reset()
+		ctx.Response.Reset()
+		ctx.Request.Header.SetMethod(method)
+		ctx.Request.SetRequestURI(uri)
+		ctx.Request.SetBodyString(body)
+		srv.HandleRequest(&ctx)
+		if ctx.Response.StatusCode() != fasthttp.StatusOK {
+			b.Fatalf("unexpected status %d", ctx.Response.StatusCode())
+		}
+	}
+}
+
+func BenchmarkRequestGetUser(b *testing.B) {
+	benchmarkRequest(b, "GET", "/users/1", "")
+}
+
+func BenchmarkRequestGetLocation(b *testing.B) {
+	benchmarkRequest(b, "GET", "/locations/1", "")
+}
+
+func BenchmarkRequestGetVisit(b *testing.B) {
+	benchmarkRequest(b, "GET", "/visits/1", "")
+}
+
+func BenchmarkRequestUserVisits(b *testing.B) {
+	benchmarkRequest(b, "GET", "/users/1/visits?toDistance=50", "")
+}
+
+func BenchmarkRequestLocationAvg(b *testing.B) {
+	benchmarkRequest(b, "GET", "/locations/1/avg?gender=m", "")
+}
+
+func BenchmarkRequestUpdateUser(b *testing.B) {
+	benchmarkRequest(b, "POST", "/users/1", `{"first_name": "Ксения", "birth_date": 316656000}`)
+}
+
+func BenchmarkRequestUpdateVisit(b *testing.B) {
+	benchmarkRequest(b, "POST", "/visits/1", `{"mark": 4}`)
+}
@@ -0,0 +1,74 @@
+package httpapi
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"runtime"
+	"strconv"
+	"unsafe"
+
+	"github.com/disc/highloadcup/model"
+	"github.com/mailru/easyjson"
+	"github.com/valyala/fasthttp"
+)
+
+//easyjson:json
+type MemoryReport struct {
+	Rss         uint64 `json:"rss"`
+	HeapAlloc   uint64 `json:"heap_alloc"`
+	HeapInuse   uint64 `json:"heap_inuse"`
+	HeapObjects uint64 `json:"heap_objects"`
+	Sys         uint64 `json:"sys"`
+	NumGC       uint32 `json:"num_gc"`
+	Users       int    `json:"users"`
+	Locations   int    `json:"locations"`
+	Visits      int    `json:"visits"`
+	VisitSize   int    `json:"visit_size"`
+}
+
+// residentSize returns the resident set size of the process in bytes, or 0
+// where /proc is not available.
+func residentSize() uint64 {
+	statm, err := ioutil.ReadFile("/proc/self/statm")
+	if err != nil {
+		return 0
+	}
+	fields := bytes.Fields(statm)
+	if len(fields) < 2 {
+		return 0
+	}
+	pages, _ := strconv.ParseUint(string(fields[1]), 10, 64)
+	return pages * uint64(os.Getpagesize())
+}
+
+// memoryRequestHandler reports the memory used by the process and the number
+// of entities it holds. With gc=1 a collection runs first, so the heap
+// figures only count live data.
+func (srv *Server) memoryRequestHandler(ctx *fasthttp.RequestCtx, args *fasthttp.Args) {
+	switch string(args.Peek("gc")) {
+	case "", "0":
+	case "1":
+		runtime.GC()
+	default:
+		srv.badRequest(ctx, &QueryError{"gc", "must be 0 or 1"})
+		return
+	}
+
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+
+	response, _ := easyjson.Marshal(MemoryReport{
+		Rss:         residentSize(),
+		HeapAlloc:   stats.HeapAlloc,
+		HeapInuse:   stats.HeapInuse,
+		HeapObjects: stats.HeapObjects,
+		Sys:         stats.Sys,
+		NumGC:       stats.NumGC,
+		Users:       srv.store.Users.Len(),
+		Locations:   srv.store.Locations.Len(),
+		Visits:      srv.store.Visits.Len(),
+		VisitSize:   int(unsafe.Sizeof(model.Visit{})),
+	})
+	ctx.Success("application/json", response)
+}
@@ -0,0 +1,183 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package httpapi
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjsonA17ce059DecodeGithubComDiscHighloadcupHttpapi(in *jlexer.Lexer, out *MemoryReport) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "rss":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Rss = uint64(in.Uint64())
+			}
+		case "heap_alloc":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.HeapAlloc = uint64(in.Uint64())
+			}
+		case "heap_inuse":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.HeapInuse = uint64(in.Uint64())
+			}
+		case "heap_objects":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.HeapObjects = uint64(in.Uint64())
+			}
+		case "sys":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Sys = uint64(in.Uint64())
+			}
+		case "num_gc":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.NumGC = uint32(in.Uint32())
+			}
+		case "users":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Users = int(in.Int())
+			}
+		case "locations":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Locations = int(in.Int())
+			}
+		case "visits":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Visits = int(in.Int())
+			}
+		case "visit_size":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.VisitSize = int(in.Int())
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonA17ce059EncodeGithubComDiscHighloadcupHttpapi(out *jwriter.Writer, in MemoryReport) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"rss\":"
+		out.RawString(prefix[1:])
+		out.Uint64(uint64(in.Rss))
+	}
+	{
+		const prefix string = ",\"heap_alloc\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.HeapAlloc))
+	}
+	{
+		const prefix string = ",\"heap_inuse\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.HeapInuse))
+	}
+	{
+		const prefix string = ",\"heap_objects\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.HeapObjects))
+	}
+	{
+		const prefix string = ",\"sys\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.Sys))
+	}
+	{
+		const prefix string = ",\"num_gc\":"
+		out.RawString(prefix)
+		out.Uint32(uint32(in.NumGC))
+	}
+	{
+		const prefix string = ",\"users\":"
+		out.RawString(prefix)
+		out.Int(int(in.Users))
+	}
+	{
+		const prefix string = ",\"locations\":"
+		out.RawString(prefix)
+		out.Int(int(in.Locations))
+	}
+	{
+		const prefix string = ",\"visits\":"
+		out.RawString(prefix)
+		out.Int(int(in.Visits))
+	}
+	{
+		const prefix string = ",\"visit_size\":"
+		out.RawString(prefix)
+		out.Int(int(in.VisitSize))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v MemoryReport) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonA17ce059EncodeGithubComDiscHighloadcupHttpapi(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v MemoryReport) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonA17ce059EncodeGithubComDiscHighloadcupHttpapi(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *MemoryReport) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonA17ce059DecodeGithubComDiscHighloadcupHttpapi(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *MemoryReport) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonA17ce059DecodeGithubComDiscHighloadcupHttpapi(l, v)
+}
@@ -0,0 +1,27 @@
+package httpapi
+
+import (
+	"testing"
+
+	"github.com/disc/highloadcup/model"
+	"github.com/mailru/easyjson"
+)
+
+func TestMemoryReport(t *testing.T) {
+	t.Parallel()
+	srv := newTestServer()
+	srv.store.Visits.Update(model.Visit{Id: 1, Location: 1, User: 1, Visited_at: 1000000000, Mark: 3})
+
+	ctx := serveRequest(srv, "GET", "/admin/memory?gc=1", "")
+	var report MemoryReport
+	if err := easyjson.Unmarshal(ctx.Response.Body(), &report); err != nil {
+		t.Fatal(err)
+	}
+	if report.Visits != 1 || report.Users != 0 || report.VisitSize != 32 || report.HeapAlloc == 0 {
+		t.Errorf("got report %+v", report)
+	}
+
+	if status := serveRequest(srv, "GET", "/admin/memory?gc=yes", "").Response.StatusCode(); status != 400 {
+		t.Errorf("got status %d for an invalid gc", status)
+	}
+}
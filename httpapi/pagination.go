@@ -0,0 +1,43 @@
+package httpapi
+
+import (
+	"strconv"
+
+	"github.com/disc/highloadcup/store"
+	"github.com/valyala/fasthttp"
+)
+
+const (
+	pageDefaultLimit = 20
+	pageMaxLimit     = 1000
+)
+
+func parsePage(args *fasthttp.Args) (store.Page, bool) {
+	var (
+		page = store.Page{Limit: pageDefaultLimit}
+		err  error
+	)
+	if args.Has("order") {
+		switch string(args.Peek("order")) {
+		case "asc":
+		case "desc":
+			page.Desc = true
+		default:
+			return page, false
+		}
+	}
+	if args.Has("offset") {
+		if page.Offset, err = strconv.Atoi(string(args.Peek("offset"))); err != nil || page.Offset < 0 {
+			return page, false
+		}
+	}
+	if args.Has("limit") {
+		if page.Limit, err = strconv.Atoi(string(args.Peek("limit"))); err != nil || page.Limit < 1 {
+			return page, false
+		}
+		if page.Limit > pageMaxLimit {
+			page.Limit = pageMaxLimit
+		}
+	}
+	return page, true
+}
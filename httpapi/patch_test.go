@@ -0,0 +1,44 @@
+package httpapi
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/disc/highloadcup/model"
+)
+
+// fuzzUpdate feeds random bodies to an update endpoint, which has to answer
+// either 200 or 400 without panicking.
+func fuzzUpdate(f *testing.F, srv *Server, uri string, seeds ...string) {
+	for _, seed := range seeds {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, body string) {
+		if status := serveRequest(srv, "POST", uri, body).Response.StatusCode(); status != 200 && status != 400 {
+			t.Errorf("%q: unexpected status %d", body, status)
+		}
+	})
+}
+
+const fuzzId = 900000100
+
+func FuzzUpdateUser(f *testing.F) {
+	srv := newTestServer()
+	srv.store.Users.Update(model.User{Id: fuzzId, Email: "fuzz@example.com", First_name: "A", Last_name: "B", Gender: "m"})
+	fuzzUpdate(f, srv, "/users/"+strconv.Itoa(fuzzId),
+		`{"email": "a@b.c", "gender": "f", "birth_date": 1}`, `{"gender": "x"}`, `{"birth_date": "1"}`, `{"last_name": null}`)
+}
+
+func FuzzUpdateLocation(f *testing.F) {
+	srv := newTestServer()
+	srv.store.Locations.Update(model.Location{Id: fuzzId, Place: "A", Country: "B", City: "C", Distance: 1})
+	fuzzUpdate(f, srv, "/locations/"+strconv.Itoa(fuzzId),
+		`{"place": "Ёлка", "distance": 5}`, `{"distance": -5}`, `{"city": 1}`, `{"country": {"a": [1]}}`)
+}
+
+func FuzzUpdateVisit(f *testing.F) {
+	srv := newTestServer()
+	srv.store.Visits.Update(model.Visit{Id: fuzzId, Location: 1, User: 1, Visited_at: 1000000000, Mark: 3})
+	fuzzUpdate(f, srv, "/visits/"+strconv.Itoa(fuzzId),
+		`{"mark": 5, "visited_at": 1}`, `{"mark": "5"}`, `{"mark": 6}`, `{"user": 1.5}`, `{"location": null}`)
+}
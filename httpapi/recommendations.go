@@ -0,0 +1,43 @@
+package httpapi
+
+import (
+	"strconv"
+
+	"github.com/disc/highloadcup/query"
+	"github.com/mailru/easyjson"
+	"github.com/valyala/fasthttp"
+)
+
+//easyjson:json
+type Recommendations struct {
+	Recommendations []query.Recommendation `json:"recommendations"`
+}
+
+const recommendationsDefaultLimit = 10
+
+func (srv *Server) recommendationsRequestHandler(ctx *fasthttp.RequestCtx, entityId uint, args *fasthttp.Args) {
+	if user := srv.store.Users.Get(entityId); user == nil {
+		srv.notFound(ctx)
+		return
+	}
+
+	var country *string
+	if args.Has("country") {
+		countryName := string(args.Peek("country"))
+		country = &countryName
+	}
+	limit := recommendationsDefaultLimit
+	if args.Has("limit") {
+		var err error
+		if limit, err = strconv.Atoi(string(args.Peek("limit"))); err != nil || limit < 1 {
+			srv.badRequest(ctx, &QueryError{"limit", "must be a positive integer"})
+			return
+		}
+		if limit > pageMaxLimit {
+			limit = pageMaxLimit
+		}
+	}
+
+	response, _ := easyjson.Marshal(Recommendations{query.GetRecommendations(srv.store, entityId, country, limit)})
+	ctx.Success("application/json", response)
+}
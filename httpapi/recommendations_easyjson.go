@@ -0,0 +1,117 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package httpapi
+
+import (
+	json "encoding/json"
+	query "github.com/disc/highloadcup/query"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson3711ab1aDecodeGithubComDiscHighloadcupHttpapi(in *jlexer.Lexer, out *Recommendations) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "recommendations":
+			if in.IsNull() {
+				in.Skip()
+				out.Recommendations = nil
+			} else {
+				in.Delim('[')
+				if out.Recommendations == nil {
+					if !in.IsDelim(']') {
+						out.Recommendations = make([]query.Recommendation, 0, 0)
+					} else {
+						out.Recommendations = []query.Recommendation{}
+					}
+				} else {
+					out.Recommendations = (out.Recommendations)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 query.Recommendation
+					if in.IsNull() {
+						in.Skip()
+					} else {
+						(v1).UnmarshalEasyJSON(in)
+					}
+					out.Recommendations = append(out.Recommendations, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3711ab1aEncodeGithubComDiscHighloadcupHttpapi(out *jwriter.Writer, in Recommendations) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"recommendations\":"
+		out.RawString(prefix[1:])
+		if in.Recommendations == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v2, v3 := range in.Recommendations {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				(v3).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Recommendations) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3711ab1aEncodeGithubComDiscHighloadcupHttpapi(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Recommendations) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3711ab1aEncodeGithubComDiscHighloadcupHttpapi(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Recommendations) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3711ab1aDecodeGithubComDiscHighloadcupHttpapi(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Recommendations) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3711ab1aDecodeGithubComDiscHighloadcupHttpapi(l, v)
+}
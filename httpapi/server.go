@@ -0,0 +1,213 @@
+// Package httpapi serves the travels API over fasthttp.
+package httpapi
+
+import (
+	"bytes"
+	"strconv"
+
+	"github.com/disc/highloadcup/query"
+	"github.com/disc/highloadcup/store"
+	"github.com/valyala/fasthttp"
+)
+
+// Server answers the HTTP API from its store. The change feed, the webhooks
+// following it and the caches are per server too.
+type Server struct {
+	Changes  *ChangeFeed
+	Webhooks *WebhookDispatcher
+
+	// CloseAfterWrite closes the connection after every create/update
+	// response, BareErrors answers errors with {} instead of the envelope.
+	CloseAfterWrite bool
+	BareErrors      bool
+
+	store        *store.Store
+	similarUsers *query.SimilarUsersCache
+}
+
+func NewServer(s *store.Store, changeFeedSize int) *Server {
+	srv := &Server{
+		Changes:      newChangeFeed(changeFeedSize),
+		Webhooks:     newWebhookDispatcher(),
+		store:        s,
+		similarUsers: query.NewSimilarUsersCache(s),
+	}
+	s.Observe(srv.Changes.observe)
+	return srv
+}
+
+func getEntityId(path []byte) uint {
+	from := bytes.IndexByte(path[1:], '/')
+	to := bytes.IndexByte(path[from+2:], '/')
+
+	if to == -1 {
+		to = len(path)
+	} else {
+		to += from + 2
+	}
+
+	entityId, _ := strconv.ParseUint(string(path[from+2:to]), 0, 32)
+
+	return uint(entityId)
+}
+
+// writeSuccessResponse answers a successful create/update. Connections are
+// kept alive unless CloseAfterWrite is set, which the contest tank expects.
+func (srv *Server) writeSuccessResponse(ctx *fasthttp.RequestCtx) {
+	if srv.CloseAfterWrite {
+		ctx.SetConnectionClose()
+	}
+	ctx.Success("application/json", []byte("{}"))
+}
+
+// HandleRequest routes a request to its handler.
+func (srv *Server) HandleRequest(ctx *fasthttp.RequestCtx) {
+	path := ctx.Path()
+
+	isGetRequest := ctx.IsGet()
+
+	if bytes.Equal(path, []byte("/admin/memory")) {
+		srv.memoryRequestHandler(ctx, ctx.QueryArgs())
+		return
+	}
+
+	if bytes.HasPrefix(path, []byte("/admin/webhooks")) {
+		if id := path[len("/admin/webhooks"):]; len(id) > 1 {
+			srv.webhookRequestHandler(ctx, id[1:])
+		} else {
+			srv.webhooksRequestHandler(ctx)
+		}
+		return
+	}
+
+	if bytes.HasPrefix(path, []byte("/countries")) {
+		if name := path[len("/countries"):]; len(name) > len("/avg")+1 && bytes.HasSuffix(name, []byte("/avg")) {
+			srv.countryAvgRequestHandler(ctx, string(name[1:len(name)-len("/avg")]), ctx.QueryArgs())
+		} else if len(name) <= 1 {
+			srv.countriesRequestHandler(ctx, ctx.QueryArgs())
+		} else {
+			srv.notFound(ctx)
+		}
+		return
+	}
+
+	if bytes.HasPrefix(path, []byte("/changes")) {
+		if bytes.HasSuffix(path, []byte("/poll")) {
+			srv.changesPollRequestHandler(ctx)
+		} else {
+			srv.changesStreamRequestHandler(ctx)
+		}
+		return
+	}
+
+	if bytes.Equal(path, []byte("/search/locations")) {
+		srv.locationsTextSearchRequestHandler(ctx, ctx.QueryArgs())
+		return
+	}
+
+	if bytes.Equal(path, []byte("/locations")) {
+		srv.locationsRequestHandler(ctx, ctx.QueryArgs())
+		return
+	}
+
+	if bytes.Equal(path, []byte("/locations/top")) {
+		srv.topLocationsRequestHandler(ctx, ctx.QueryArgs())
+		return
+	}
+
+	// The routes below look at the path by position.
+	if len(path) < 2 {
+		srv.notFound(ctx)
+		return
+	}
+
+	if path[1] == 'l' && bytes.HasSuffix(path, []byte("/timeline")) {
+		srv.locationTimelineRequestHandler(ctx, getEntityId(path), ctx.QueryArgs())
+		return
+	}
+
+	if path[1] == 'l' && bytes.HasSuffix(path, []byte("/visits")) {
+		srv.locationVisitsRequestHandler(ctx, getEntityId(path), ctx.QueryArgs())
+		return
+	}
+
+	if path[1] == 'l' && path[len(path)-1] == 'g' {
+		srv.locationAvgRequestHandler(ctx, getEntityId(path), ctx.QueryArgs())
+		return
+	}
+
+	if bytes.HasPrefix(path, []byte(userByEmailPrefix)) {
+		srv.userByEmailRequestHandler(ctx, string(path[len(userByEmailPrefix):]))
+		return
+	}
+
+	if bytes.Equal(path, []byte("/users")) {
+		srv.usersRequestHandler(ctx, ctx.QueryArgs())
+		return
+	}
+
+	if path[1] == 'u' && bytes.HasSuffix(path, []byte("/recommendations")) {
+		srv.recommendationsRequestHandler(ctx, getEntityId(path), ctx.QueryArgs())
+		return
+	}
+
+	if path[1] == 'u' && bytes.HasSuffix(path, []byte("/similar")) {
+		srv.similarUsersRequestHandler(ctx, getEntityId(path), ctx.QueryArgs())
+		return
+	}
+
+	if path[1] == 'u' && bytes.HasSuffix(path, []byte("/stats")) {
+		srv.userStatsRequestHandler(ctx, getEntityId(path), ctx.QueryArgs())
+		return
+	}
+
+	if path[1] == 'u' && path[len(path)-1] == 's' && len(path) >= 14 {
+		srv.userVisitsRequestHandler(ctx, getEntityId(path), ctx.QueryArgs())
+		return
+	}
+
+	if len(path) > 6 && path[1] == 'v' && path[6] == 's' {
+		if path[len(path)-1] == 'y' {
+			srv.visitHistoryRequestHandler(ctx, getEntityId(path))
+		} else if path[len(path)-1] == 'w' {
+			srv.createVisitRequestHandler(ctx)
+		} else {
+			if isGetRequest {
+				srv.getVisitRequestHandler(ctx, getEntityId(path))
+			} else {
+				srv.updateVisitRequestHandler(ctx, getEntityId(path))
+			}
+		}
+		return
+	}
+
+	if len(path) > 5 && path[1] == 'u' && path[5] == 's' {
+		if path[len(path)-1] == 'w' {
+			srv.createUserRequestHandler(ctx)
+		} else {
+			id := getEntityId(path)
+			if isGetRequest {
+				srv.getUserRequestHandler(ctx, id)
+			} else {
+				srv.updateUserRequestHandler(ctx, id)
+			}
+		}
+		return
+	}
+
+	if len(path) > 9 && path[1] == 'l' && path[9] == 's' {
+		if path[len(path)-1] == 'w' {
+			srv.createLocationRequestHandler(ctx)
+		} else {
+			id := getEntityId(path)
+			if isGetRequest {
+				srv.getLocationRequestHandler(ctx, id)
+			} else {
+				srv.updateLocationRequestHandler(ctx, id)
+			}
+		}
+		return
+	}
+
+	srv.notFound(ctx)
+}
@@ -0,0 +1,68 @@
+package httpapi
+
+import (
+	"strconv"
+
+	"github.com/disc/highloadcup/query"
+	"github.com/mailru/easyjson"
+	"github.com/valyala/fasthttp"
+)
+
+//easyjson:json
+type SimilarUsers struct {
+	Users []query.SimilarUser `json:"users"`
+}
+
+const similarUsersDefaultLimit = 10
+
+func parseSimilarUsersFilter(args *fasthttp.Args) (query.SimilarUsersFilter, bool) {
+	filter := query.SimilarUsersFilter{Limit: similarUsersDefaultLimit}
+	if args.Has("gender") {
+		gender := string(args.Peek("gender"))
+		if gender != "m" && gender != "f" {
+			return filter, false
+		}
+		filter.Gender = &gender
+	}
+	if args.Has("fromAge") {
+		fromAge, err := strconv.Atoi(string(args.Peek("fromAge")))
+		if err != nil {
+			return filter, false
+		}
+		filter.FromAge = &fromAge
+	}
+	if args.Has("toAge") {
+		toAge, err := strconv.Atoi(string(args.Peek("toAge")))
+		if err != nil {
+			return filter, false
+		}
+		filter.ToAge = &toAge
+	}
+	if args.Has("limit") {
+		limit, err := strconv.Atoi(string(args.Peek("limit")))
+		if err != nil || limit < 1 {
+			return filter, false
+		}
+		if limit > pageMaxLimit {
+			limit = pageMaxLimit
+		}
+		filter.Limit = limit
+	}
+	return filter, true
+}
+
+func (srv *Server) similarUsersRequestHandler(ctx *fasthttp.RequestCtx, entityId uint, args *fasthttp.Args) {
+	if user := srv.store.Users.Get(entityId); user == nil {
+		srv.notFound(ctx)
+		return
+	}
+
+	filter, ok := parseSimilarUsersFilter(args)
+	if !ok {
+		srv.badRequest(ctx, errInvalidQuery)
+		return
+	}
+
+	response, _ := easyjson.Marshal(SimilarUsers{srv.similarUsers.GetSimilarUsers(entityId, filter)})
+	ctx.Success("application/json", response)
+}
@@ -0,0 +1,16 @@
+package httpapi
+
+import (
+	"github.com/valyala/fasthttp"
+)
+
+const userByEmailPrefix = "/users/by-email/"
+
+func (srv *Server) userByEmailRequestHandler(ctx *fasthttp.RequestCtx, email string) {
+	id, ok := srv.store.Users.EmailOwner(email)
+	if !ok {
+		srv.notFound(ctx)
+		return
+	}
+	srv.getUserRequestHandler(ctx, id)
+}
@@ -0,0 +1,164 @@
+package httpapi
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/valyala/fasthttp"
+)
+
+// serveRequest serves a request with the given header name/value pairs.
+func serveRequest(srv *Server, method string, uri string, body string, headers ...string) *fasthttp.RequestCtx {
+	var ctx fasthttp.RequestCtx
+	ctx.Request.Header.SetMethod(method)
+	ctx.Request.SetRequestURI(uri)
+	ctx.Request.SetBodyString(body)
+	for i := 0; i+1 < len(headers); i += 2 {
+		if headers[i] != "" {
+			ctx.Request.Header.Set(headers[i], headers[i+1])
+		}
+	}
+	srv.HandleRequest(&ctx)
+	return &ctx
+}
+
+func TestUniqueEmail(t *testing.T) {
+	const (
+		firstId = 900000300
+		email   = "unique@example.com"
+	)
+	t.Parallel()
+	srv := newTestServer()
+
+	// Concurrent creates with the same email: exactly one may win.
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		accepted []int
+	)
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			body := `{"id": ` + strconv.Itoa(id) + `, "email": "` + email + `", "first_name": "A", "last_name": "B", "gender": "m", "birth_date": 0}`
+			if serveRequest(srv, "POST", "/users/new", body).Response.StatusCode() == 200 {
+				mu.Lock()
+				accepted = append(accepted, id)
+				mu.Unlock()
+			}
+		}(firstId + i)
+	}
+	wg.Wait()
+	if len(accepted) != 1 {
+		t.Fatalf("%d creates with the same email were accepted", len(accepted))
+	}
+	owner := strconv.Itoa(accepted[0])
+
+	waitFor := func(uri string, status int) *fasthttp.RequestCtx {
+		for i := 0; ; i++ {
+			ctx := serveRequest(srv, "GET", uri, "")
+			if ctx.Response.StatusCode() == status || i == 100 {
+				return ctx
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+	if ctx := waitFor("/users/by-email/"+email, 200); ctx.Response.StatusCode() != 200 ||
+		!strings.Contains(string(ctx.Response.Body()), `"id":`+owner) {
+		t.Fatalf("lookup by email answered %d %s", ctx.Response.StatusCode(), ctx.Response.Body())
+	}
+
+	// Changing the email frees the old one.
+	if status := serveRequest(srv, "POST", "/users/"+owner, `{"email": "changed@example.com"}`).Response.StatusCode(); status != 200 {
+		t.Fatalf("email change answered %d", status)
+	}
+	if status := waitFor("/users/by-email/"+email, 404).Response.StatusCode(); status != 404 {
+		t.Errorf("old email lookup answered %d", status)
+	}
+	if status := waitFor("/users/by-email/changed@example.com", 200).Response.StatusCode(); status != 200 {
+		t.Errorf("new email lookup answered %d", status)
+	}
+	other := strconv.Itoa(firstId + 8)
+	body := `{"id": ` + other + `, "email": "` + email + `", "first_name": "A", "last_name": "B", "gender": "m", "birth_date": 0}`
+	if status := serveRequest(srv, "POST", "/users/new", body).Response.StatusCode(); status != 200 {
+		t.Errorf("create with a freed email answered %d", status)
+	}
+	waitFor("/users/"+other, 200)
+	if status := serveRequest(srv, "POST", "/users/"+other, `{"email": "changed@example.com"}`).Response.StatusCode(); status != 400 {
+		t.Errorf("update to a taken email answered %d", status)
+	}
+}
+
+// TestUniqueEmailRaces creates one user with different emails and moves two
+// users to the same email concurrently. One request of each may win, and the
+// email index has to follow the stored users.
+func TestUniqueEmailRaces(t *testing.T) {
+	const (
+		userId = 900000400
+		email  = "race@example.com"
+	)
+	t.Parallel()
+	srv := newTestServer()
+
+	race := func(requests ...func() int) []int {
+		var (
+			wg       sync.WaitGroup
+			mu       sync.Mutex
+			accepted []int
+		)
+		for i, request := range requests {
+			wg.Add(1)
+			go func(i int, request func() int) {
+				defer wg.Done()
+				if request() == 200 {
+					mu.Lock()
+					accepted = append(accepted, i)
+					mu.Unlock()
+				}
+			}(i, request)
+		}
+		wg.Wait()
+		return accepted
+	}
+
+	var creates []func() int
+	for i := 0; i < 8; i++ {
+		body := `{"id": ` + strconv.Itoa(userId) + `, "email": "create` + strconv.Itoa(i) + `@example.com", "first_name": "A", "last_name": "B", "gender": "m", "birth_date": 0}`
+		creates = append(creates, func() int { return serveRequest(srv, "POST", "/users/new", body).Response.StatusCode() })
+	}
+	accepted := race(creates...)
+	if len(accepted) != 1 {
+		t.Fatalf("%d creates of one user were accepted", len(accepted))
+	}
+	for i := 0; i < 8; i++ {
+		owner, ok := srv.store.Users.EmailOwner("create" + strconv.Itoa(i) + "@example.com")
+		if i == accepted[0] && (!ok || owner != userId) || i != accepted[0] && ok {
+			t.Errorf("email %d: got owner %d, %v", i, owner, ok)
+		}
+	}
+
+	other := strconv.Itoa(userId + 1)
+	body := `{"id": ` + other + `, "email": "other@example.com", "first_name": "A", "last_name": "B", "gender": "m", "birth_date": 0}`
+	if status := serveRequest(srv, "POST", "/users/new", body).Response.StatusCode(); status != 200 {
+		t.Fatalf("create answered %d", status)
+	}
+	for round := 0; round < 20; round++ {
+		claim := `{"email": "` + email + strconv.Itoa(round) + `"}`
+		accepted := race(
+			func() int {
+				return serveRequest(srv, "POST", "/users/"+strconv.Itoa(userId), claim).Response.StatusCode()
+			},
+			func() int { return serveRequest(srv, "POST", "/users/"+other, claim).Response.StatusCode() },
+		)
+		if len(accepted) != 1 {
+			t.Fatalf("round %d: %d updates to one email were accepted", round, len(accepted))
+		}
+		owner, ok := srv.store.Users.EmailOwner(email + strconv.Itoa(round))
+		if user := srv.store.Users.Get(owner); !ok || user == nil || user.Email != email+strconv.Itoa(round) {
+			t.Fatalf("round %d: email points at %d, %v", round, owner, ok)
+		}
+	}
+}
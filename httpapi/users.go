@@ -0,0 +1,131 @@
+package httpapi
+
+import (
+	"errors"
+
+	"github.com/disc/highloadcup/model"
+	"github.com/disc/highloadcup/store"
+	"github.com/mailru/easyjson"
+	"github.com/valyala/fasthttp"
+)
+
+var errUserExists = errors.New("User already exists")
+
+func (srv *Server) getUserRequestHandler(ctx *fasthttp.RequestCtx, entityId uint) {
+	asOf, err := parseAsOf(ctx.QueryArgs())
+	if err != nil {
+		srv.badRequest(ctx, err)
+		return
+	}
+
+	user := srv.store.Users.Get(entityId)
+	if asOf != nil {
+		user = srv.store.Users.GetAsOf(entityId, *asOf)
+	}
+	if user != nil {
+		if notModified(ctx, user.Version) {
+			return
+		}
+		response, _ := easyjson.Marshal(user)
+		ctx.Success("application/json", response)
+		return
+	}
+	srv.notFound(ctx)
+}
+
+func (srv *Server) createUserRequestHandler(ctx *fasthttp.RequestCtx) {
+	user, err := createUser(srv.store.Users, ctx.PostBody())
+	if err != nil {
+		srv.badRequest(ctx, err)
+		return
+	}
+	// The email is checked under the same lock the user is stored with.
+	var created uint
+	if _, err := srv.store.Users.UpdateUnique(*user, &created); err == store.ErrVersionMismatch {
+		srv.badRequest(ctx, errUserExists)
+		return
+	} else if err != nil {
+		srv.writeFailed(ctx, err)
+		return
+	}
+	srv.writeSuccessResponse(ctx)
+}
+
+func (srv *Server) updateUserRequestHandler(ctx *fasthttp.RequestCtx, entityId uint) {
+	if user := srv.store.Users.Get(entityId); user != nil {
+		if preconditionFailed(ctx, user.Version) {
+			srv.writePreconditionFailed(ctx)
+			return
+		}
+		updatedUser, err := updateUser(srv.store.Users, ctx.PostBody(), user)
+		if err != nil {
+			srv.badRequest(ctx, err)
+			return
+		}
+		var version *uint
+		if hasIfMatch(ctx) {
+			version = &user.Version
+		}
+		if _, err := srv.store.Users.UpdateUnique(*updatedUser, version); err == store.ErrVersionMismatch {
+			srv.writePreconditionFailed(ctx)
+			return
+		} else if err != nil {
+			srv.writeFailed(ctx, err)
+			return
+		}
+		if version != nil {
+			setEntityTag(ctx, user.Version+1)
+		}
+		srv.writeSuccessResponse(ctx)
+		return
+	}
+	srv.notFound(ctx)
+}
+
+func createUser(users store.UserRepository, postData []byte) (*model.User, error) {
+	user := model.User{}
+	if err := easyjson.Unmarshal(postData, &user); err != nil {
+		return nil, err
+	}
+
+	if user := users.Get(user.Id); user != nil {
+		return nil, errUserExists
+	}
+	if err := users.Validate(&user); err != nil {
+		return nil, err
+	}
+	user.UpdatedAt = revisionTime()
+
+	return &user, nil
+}
+
+func updateUser(users store.UserRepository, postBody []byte, user *model.User) (*model.User, error) {
+	var patch model.UserPatch
+	if err := easyjson.Unmarshal(postBody, &patch); err != nil {
+		return nil, err
+	}
+
+	updatedUser := *user
+	updatedUser.UpdatedAt = revisionTime()
+
+	if patch.Email != nil {
+		updatedUser.Email = *patch.Email
+	}
+	if patch.First_name != nil {
+		updatedUser.First_name = *patch.First_name
+	}
+	if patch.Last_name != nil {
+		updatedUser.Last_name = *patch.Last_name
+	}
+	if patch.Gender != nil {
+		updatedUser.Gender = *patch.Gender
+	}
+	if patch.Birth_date != nil {
+		updatedUser.Birth_date = *patch.Birth_date
+	}
+	if err := users.Validate(&updatedUser); err != nil {
+		return nil, err
+	}
+
+	return &updatedUser, nil
+}
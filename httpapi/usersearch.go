@@ -0,0 +1,102 @@
+package httpapi
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/disc/highloadcup/model"
+	"github.com/disc/highloadcup/store"
+	"github.com/mailru/easyjson"
+	"github.com/valyala/fasthttp"
+)
+
+//easyjson:json
+type UsersPage struct {
+	Users []model.User `json:"users"`
+	Total int          `json:"total"`
+}
+
+func parseUserSearchFilter(args *fasthttp.Args, now int) (store.UserSearchFilter, bool) {
+	var filter = store.UserSearchFilter{Sort: "id"}
+
+	if args.Has("gender") {
+		gender := string(args.Peek("gender"))
+		if gender != "m" && gender != "f" {
+			return filter, false
+		}
+		filter.Gender = &gender
+	}
+	if args.Has("emailDomain") {
+		domain := strings.ToLower(string(args.Peek("emailDomain")))
+		filter.EmailDomain = &domain
+	}
+	if args.Has("firstName") {
+		firstName := strings.ToLower(string(args.Peek("firstName")))
+		filter.FirstName = &firstName
+	}
+	if args.Has("lastName") {
+		lastName := strings.ToLower(string(args.Peek("lastName")))
+		filter.LastName = &lastName
+	}
+
+	intArgs := []struct {
+		name  string
+		value **int
+	}{
+		{"fromBirthDate", &filter.FromBirthDate},
+		{"toBirthDate", &filter.ToBirthDate},
+	}
+	for _, arg := range intArgs {
+		if args.Has(arg.name) {
+			value, err := strconv.Atoi(string(args.Peek(arg.name)))
+			if err != nil {
+				return filter, false
+			}
+			*arg.value = &value
+		}
+	}
+
+	// Ages narrow the birth date range the same way /locations/:id/avg does.
+	if args.Has("fromAge") {
+		fromAge, err := strconv.Atoi(string(args.Peek("fromAge")))
+		if err != nil {
+			return filter, false
+		}
+		if toBirthDate := model.TimestampByAge(&fromAge, now); filter.ToBirthDate == nil || toBirthDate < *filter.ToBirthDate {
+			filter.ToBirthDate = &toBirthDate
+		}
+	}
+	if args.Has("toAge") {
+		toAge, err := strconv.Atoi(string(args.Peek("toAge")))
+		if err != nil {
+			return filter, false
+		}
+		if fromBirthDate := model.TimestampByAge(&toAge, now) + 1; filter.FromBirthDate == nil || fromBirthDate > *filter.FromBirthDate {
+			filter.FromBirthDate = &fromBirthDate
+		}
+	}
+
+	if args.Has("sort") {
+		switch filter.Sort = string(args.Peek("sort")); filter.Sort {
+		case "id", "birth_date", "first_name", "last_name":
+		default:
+			return filter, false
+		}
+	}
+
+	var ok bool
+	filter.Page, ok = parsePage(args)
+	return filter, ok
+}
+
+func (srv *Server) usersRequestHandler(ctx *fasthttp.RequestCtx, args *fasthttp.Args) {
+	filter, ok := parseUserSearchFilter(args, srv.store.Now)
+	if !ok {
+		srv.badRequest(ctx, errInvalidQuery)
+		return
+	}
+
+	users, total := srv.store.Users.Search(filter)
+	response, _ := easyjson.Marshal(UsersPage{users, total})
+	ctx.Success("application/json", response)
+}
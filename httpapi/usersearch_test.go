@@ -0,0 +1,124 @@
+package httpapi
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/disc/highloadcup/model"
+	"github.com/disc/highloadcup/store"
+	"github.com/mailru/easyjson"
+)
+
+const (
+	searchNow  = 40 * searchYear
+	searchYear = 31557600
+)
+
+// newUserSearchServer serves five users; the birth dates make them 20, 30,
+// 25, 40 and 18 years old at searchNow.
+func newUserSearchServer() *Server {
+	srv := NewServer(store.NewStore(0), 100)
+	srv.store.Now = searchNow
+	for _, user := range []model.User{
+		{Id: 1, Email: "a@mail.ru", First_name: "Анна", Last_name: "Иванова", Gender: "f", Birth_date: searchNow - 20*searchYear - 1000},
+		{Id: 2, Email: "b@gmail.com", First_name: "Борис", Last_name: "Петров", Gender: "m", Birth_date: searchNow - 30*searchYear - 1000},
+		{Id: 3, Email: "c@mail.ru", First_name: "Алексей", Last_name: "Сидоров", Gender: "m", Birth_date: searchNow - 25*searchYear - 1000},
+		{Id: 4, Email: "d@MAIL.ru", First_name: "Вера", Last_name: "Андреева", Gender: "f", Birth_date: searchNow - 40*searchYear - 1000},
+		{Id: 5, Email: "e@yandex.ru", First_name: "антон", Last_name: "Иванов", Gender: "m", Birth_date: searchNow - 18*searchYear - 1000},
+	} {
+		srv.store.Users.Update(user)
+	}
+	return srv
+}
+
+// searchUsers returns the ids of the page and the total, or the status of
+// a failed request.
+func searchUsers(srv *Server, uri string) (string, int, int) {
+	ctx := serveRequest(srv, "GET", uri, "")
+	if status := ctx.Response.StatusCode(); status != 200 {
+		return "", 0, status
+	}
+	var page UsersPage
+	easyjson.Unmarshal(ctx.Response.Body(), &page)
+	ids := make([]uint, 0, len(page.Users))
+	for _, user := range page.Users {
+		ids = append(ids, user.Id)
+	}
+	return fmt.Sprint(ids), page.Total, 200
+}
+
+func TestUserSearch(t *testing.T) {
+	t.Parallel()
+	srv := newUserSearchServer()
+
+	tests := []struct {
+		uri    string
+		status int
+		ids    string
+		total  int
+	}{
+		{"/users", 200, "[1 2 3 4 5]", 5},
+		{"/users?gender=f", 200, "[1 4]", 2},
+		{"/users?emailDomain=mail.ru", 200, "[1 3 4]", 3},
+		{"/users?emailDomain=Mail.RU&gender=m", 200, "[3]", 1},
+		{"/users?firstName=%D0%B0", 200, "[1 3 5]", 3},
+		{"/users?firstName=%D0%90%D0%BD", 200, "[1 5]", 2},
+		{"/users?lastName=%D0%B8%D0%B2%D0%B0%D0%BD%D0%BE%D0%B2", 200, "[1 5]", 2},
+		{"/users?fromAge=25", 200, "[2 3 4]", 3},
+		{"/users?fromAge=25&toAge=30", 200, "[3]", 1},
+		{fmt.Sprintf("/users?fromBirthDate=%d&toBirthDate=%d", searchNow-30*searchYear, searchNow), 200, "[1 3 5]", 3},
+		{"/users?sort=birth_date", 200, "[4 2 3 1 5]", 5},
+		{"/users?sort=first_name", 200, "[3 1 5 2 4]", 5},
+		{"/users?sort=last_name&order=desc", 200, "[3 2 1 5 4]", 5},
+		{"/users?sort=first_name&firstName=%D0%B0", 200, "[3 1 5]", 3},
+		{"/users?limit=2", 200, "[1 2]", 5},
+		{"/users?limit=2&offset=2", 200, "[3 4]", 5},
+		{"/users?limit=2&offset=4", 200, "[5]", 5},
+		{"/users?offset=10", 200, "[]", 5},
+		{"/users?order=desc&limit=2", 200, "[5 4]", 5},
+		{"/users?gender=x", 400, "", 0},
+		{"/users?fromAge=x", 400, "", 0},
+		{"/users?toBirthDate=x", 400, "", 0},
+		{"/users?sort=email", 400, "", 0},
+		{"/users?order=up", 400, "", 0},
+		{"/users?limit=0", 400, "", 0},
+		{"/users?offset=-1", 400, "", 0},
+	}
+	for _, test := range tests {
+		ids, total, status := searchUsers(srv, test.uri)
+		if status != test.status || ids != test.ids || total != test.total {
+			t.Errorf("%s: got %d %s of %d, want %d %s of %d", test.uri, status, ids, total, test.status, test.ids, test.total)
+		}
+	}
+}
+
+// TestUserSearchAfterWrites searches between writes, so the index built by
+// the first search has to follow the users changed and created since.
+func TestUserSearchAfterWrites(t *testing.T) {
+	t.Parallel()
+	srv := newUserSearchServer()
+	searchUsers(srv, "/users")
+
+	srv.store.Users.Update(model.User{Id: 2, Email: "b@mail.ru", First_name: "Аркадий", Last_name: "Петров", Gender: "m", Birth_date: searchNow - 50*searchYear})
+	srv.store.Users.Update(model.User{Id: 6, Email: "f@mail.ru", First_name: "Белла", Last_name: "Яковлева", Gender: "f", Birth_date: searchNow})
+
+	tests := []struct {
+		uri   string
+		ids   string
+		total int
+	}{
+		{"/users", "[1 2 3 4 5 6]", 6},
+		{"/users?emailDomain=gmail.com", "[]", 0},
+		{"/users?emailDomain=mail.ru", "[1 2 3 4 6]", 5},
+		{"/users?firstName=%D0%B0", "[1 2 3 5]", 4},
+		{"/users?firstName=%D0%B1", "[6]", 1},
+		{"/users?sort=first_name", "[3 1 5 2 6 4]", 6},
+		{"/users?sort=birth_date&limit=2", "[2 4]", 6},
+		{"/users?fromAge=45", "[2]", 1},
+	}
+	for _, test := range tests {
+		if ids, total, _ := searchUsers(srv, test.uri); ids != test.ids || total != test.total {
+			t.Errorf("%s: got %s of %d, want %s of %d", test.uri, ids, total, test.ids, test.total)
+		}
+	}
+}
@@ -0,0 +1,23 @@
+package httpapi
+
+import (
+	"github.com/disc/highloadcup/query"
+	"github.com/mailru/easyjson"
+	"github.com/valyala/fasthttp"
+)
+
+func (srv *Server) userStatsRequestHandler(ctx *fasthttp.RequestCtx, entityId uint, args *fasthttp.Args) {
+	if user := srv.store.Users.Get(entityId); user == nil {
+		srv.notFound(ctx)
+		return
+	}
+
+	filters, ok := parseUserVisitsFilter(args)
+	if !ok {
+		srv.badRequest(ctx, errInvalidQuery)
+		return
+	}
+
+	response, _ := easyjson.Marshal(query.GetUserStats(srv.store, entityId, filters))
+	ctx.Success("application/json", response)
+}
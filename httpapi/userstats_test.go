@@ -0,0 +1,62 @@
+package httpapi
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/disc/highloadcup/model"
+	"github.com/disc/highloadcup/query"
+	"github.com/mailru/easyjson"
+)
+
+func TestUserStats(t *testing.T) {
+	t.Parallel()
+	srv := newLocationsServer()
+	// A visit to a location that does not exist counts for nothing.
+	srv.store.Visits.Update(model.Visit{Id: 20, Location: 99, User: 1, Visited_at: 8000, Mark: 5})
+	visit := func(at int) *int { return &at }
+	stats := func(visits, locations, countries int, avg float64, first, last *int, byCountry map[string]int) query.UserStats {
+		return query.UserStats{
+			Visits: visits, Locations: locations, Countries: countries, AvgMark: avg,
+			FirstVisit: first, LastVisit: last, ByCountry: byCountry,
+		}
+	}
+
+	tests := []struct {
+		uri    string
+		status int
+		stats  query.UserStats
+	}{
+		{"/users/1/stats", 200, stats(3, 3, 1, 3.33333, visit(1000), visit(6000), map[string]int{"Россия": 3})},
+		{"/users/3/stats", 200, stats(2, 2, 2, 3, visit(4000), visit(7000), map[string]int{"Россия": 1, "Италия": 1})},
+		{"/users/3/stats?country=" + url.QueryEscape("Италия"), 200, stats(1, 1, 1, 1, visit(7000), visit(7000), map[string]int{"Италия": 1})},
+		{"/users/1/stats?toDistance=20", 200, stats(1, 1, 1, 5, visit(1000), visit(1000), map[string]int{"Россия": 1})},
+		{"/users/1/stats?fromDate=2000&toDate=6000", 200, stats(2, 2, 1, 2.5, visit(3000), visit(6000), map[string]int{"Россия": 2})},
+		{"/users/4/stats", 200, query.UserStats{ByCountry: map[string]int{}}},
+		{"/users/99/stats", 404, query.UserStats{}},
+		{"/users/1/stats?fromDate=x", 400, query.UserStats{}},
+		{"/users/1/stats?toDistance=x", 400, query.UserStats{}},
+	}
+	for _, test := range tests {
+		ctx := serveRequest(srv, "GET", test.uri, "")
+		if status := ctx.Response.StatusCode(); status != test.status {
+			t.Errorf("%s: got status %d, want %d", test.uri, status, test.status)
+			continue
+		}
+		if test.status != 200 {
+			continue
+		}
+		var stats query.UserStats
+		easyjson.Unmarshal(ctx.Response.Body(), &stats)
+		if !reflect.DeepEqual(stats, test.stats) {
+			t.Errorf("%s: got %s", test.uri, ctx.Response.Body())
+		}
+	}
+
+	ctx := serveRequest(srv, "GET", "/users/4/stats", "")
+	want := `{"visits":0,"locations":0,"countries":0,"avg_mark":0,"first_visit":null,"last_visit":null,"by_country":{}}`
+	if body := string(ctx.Response.Body()); body != want {
+		t.Errorf("got %s, want %s", body, want)
+	}
+}
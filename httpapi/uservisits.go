@@ -0,0 +1,75 @@
+package httpapi
+
+import (
+	"strconv"
+
+	"github.com/disc/highloadcup/query"
+	"github.com/mailru/easyjson"
+	"github.com/valyala/fasthttp"
+)
+
+//easyjson:json
+type UserVisits struct {
+	Visits []query.UserVisit `json:"visits"`
+}
+
+func (srv *Server) userVisitsRequestHandler(ctx *fasthttp.RequestCtx, entityId uint, args *fasthttp.Args) {
+	asOf, err := parseAsOf(args)
+	if err != nil {
+		srv.badRequest(ctx, err)
+		return
+	}
+	user := srv.store.Users.Get(entityId)
+	if asOf != nil {
+		user = srv.store.Users.GetAsOf(entityId, *asOf)
+	}
+	if user == nil {
+		srv.notFound(ctx)
+		return
+	}
+
+	filters, ok := parseUserVisitsFilter(args)
+	if !ok {
+		srv.badRequest(ctx, errInvalidQuery)
+		return
+	}
+	filters.AsOf = asOf
+
+	response, _ := easyjson.Marshal(UserVisits{query.GetUserVisits(srv.store, entityId, filters)})
+	ctx.Success("application/json", response)
+}
+
+func parseUserVisitsFilter(args *fasthttp.Args) (query.UserVisitsFilter, bool) {
+	var filters = query.UserVisitsFilter{}
+	if args.Len() > 0 {
+		if fromDate := args.Has("fromDate"); fromDate {
+			if fromDateInt, err := strconv.Atoi(string(args.Peek("fromDate"))); err != nil {
+				return filters, false
+			} else {
+				filters.FromDate = &fromDateInt
+			}
+		}
+		if toDate := args.Has("toDate"); toDate {
+			if toDateInt, err := strconv.Atoi(string(args.Peek("toDate"))); err != nil {
+				return filters, false
+			} else {
+				filters.ToDate = &toDateInt
+			}
+		}
+		if country := args.Has("country"); country {
+			countryName := string(args.Peek("country"))
+			filters.Country = &countryName
+		}
+		if toDistance := args.Has("toDistance"); toDistance {
+			// get location id by Country
+			if distanceInt, err := strconv.Atoi(string(args.Peek("toDistance"))); err != nil {
+				return filters, false
+			} else {
+				distanceInt := uint(distanceInt)
+				filters.ToDistance = &distanceInt
+			}
+		}
+	}
+
+	return filters, true
+}
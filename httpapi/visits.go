@@ -0,0 +1,147 @@
+package httpapi
+
+import (
+	"errors"
+
+	"github.com/disc/highloadcup/model"
+	"github.com/disc/highloadcup/store"
+	"github.com/mailru/easyjson"
+	"github.com/valyala/fasthttp"
+)
+
+//easyjson:json
+type VisitRevision struct {
+	Visit     model.Visit `json:"visit"`
+	Version   uint        `json:"version"`
+	UpdatedAt int         `json:"updated_at"`
+}
+
+//easyjson:json
+type VisitHistory struct {
+	History []VisitRevision `json:"history"`
+}
+
+func (srv *Server) getVisitRequestHandler(ctx *fasthttp.RequestCtx, entityId uint) {
+	asOf, err := parseAsOf(ctx.QueryArgs())
+	if err != nil {
+		srv.badRequest(ctx, err)
+		return
+	}
+
+	visit := srv.store.Visits.Get(entityId)
+	if asOf != nil {
+		visit = srv.store.Visits.GetAsOf(entityId, *asOf)
+	}
+	if visit != nil {
+		if notModified(ctx, uint(visit.Version)) {
+			return
+		}
+		response, _ := easyjson.Marshal(visit)
+		ctx.Success("application/json", response)
+		return
+	}
+	srv.notFound(ctx)
+}
+
+func (srv *Server) visitHistoryRequestHandler(ctx *fasthttp.RequestCtx, entityId uint) {
+	revisions := srv.store.Visits.History(entityId)
+	if revisions == nil {
+		srv.notFound(ctx)
+		return
+	}
+
+	history := VisitHistory{make([]VisitRevision, 0, len(revisions))}
+	for _, visit := range revisions {
+		history.History = append(history.History, VisitRevision{visit, uint(visit.Version), visit.UpdatedAt})
+	}
+	response, _ := easyjson.Marshal(history)
+	ctx.Success("application/json", response)
+}
+
+func (srv *Server) createVisitRequestHandler(ctx *fasthttp.RequestCtx) {
+	visit, err := createVisit(srv.store.Visits, ctx.PostBody())
+	if err != nil {
+		srv.badRequest(ctx, err)
+		return
+	}
+	if version, _ := srv.store.Visits.Update(*visit); version == 0 {
+		srv.writeFailed(ctx, store.ErrWriteFailed)
+		return
+	}
+	srv.writeSuccessResponse(ctx)
+}
+
+func (srv *Server) updateVisitRequestHandler(ctx *fasthttp.RequestCtx, entityId uint) {
+	if visit := srv.store.Visits.Get(entityId); visit != nil {
+		if preconditionFailed(ctx, uint(visit.Version)) {
+			srv.writePreconditionFailed(ctx)
+			return
+		}
+		updatedVisit, err := updateVisit(ctx.PostBody(), *visit)
+		if err != nil {
+			srv.badRequest(ctx, err)
+			return
+		}
+		if hasIfMatch(ctx) {
+			if _, err := srv.store.Visits.UpdateIfVersion(*updatedVisit, uint(visit.Version)); err == store.ErrVersionMismatch {
+				srv.writePreconditionFailed(ctx)
+				return
+			} else if err != nil {
+				srv.writeFailed(ctx, err)
+				return
+			}
+			setEntityTag(ctx, uint(visit.Version)+1)
+		} else if version, _ := srv.store.Visits.Update(*updatedVisit); version == 0 {
+			srv.writeFailed(ctx, store.ErrWriteFailed)
+			return
+		}
+		srv.writeSuccessResponse(ctx)
+		return
+	}
+	srv.notFound(ctx)
+}
+
+func createVisit(visits store.VisitRepository, postData []byte) (*model.Visit, error) {
+	visit := model.Visit{}
+	if err := easyjson.Unmarshal(postData, &visit); err != nil {
+		return nil, err
+	}
+
+	if err := model.ValidateVisit(&visit); err != nil {
+		return nil, err
+	}
+	if visit := visits.Get(uint(visit.Id)); visit != nil {
+		return nil, errors.New("Visit already exists")
+	}
+	visit.UpdatedAt = revisionTime()
+
+	return &visit, nil
+}
+
+func updateVisit(postData []byte, visit model.Visit) (*model.Visit, error) {
+	var patch model.VisitPatch
+	if err := easyjson.Unmarshal(postData, &patch); err != nil {
+		return nil, err
+	}
+
+	updatedVisit := visit
+	updatedVisit.UpdatedAt = revisionTime()
+
+	if patch.Location != nil {
+		updatedVisit.Location = *patch.Location
+	}
+	if patch.User != nil {
+		updatedVisit.User = *patch.User
+	}
+	if patch.Visited_at != nil {
+		updatedVisit.Visited_at = *patch.Visited_at
+	}
+	if patch.Mark != nil {
+		updatedVisit.Mark = *patch.Mark
+	}
+	if err := model.ValidateVisit(&updatedVisit); err != nil {
+		return nil, err
+	}
+
+	return &updatedVisit, nil
+}
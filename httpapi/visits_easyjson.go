@@ -0,0 +1,203 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package httpapi
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjsonEada991cDecodeGithubComDiscHighloadcupHttpapi(in *jlexer.Lexer, out *VisitRevision) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "visit":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				(out.Visit).UnmarshalEasyJSON(in)
+			}
+		case "version":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Version = uint(in.Uint())
+			}
+		case "updated_at":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.UpdatedAt = int(in.Int())
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonEada991cEncodeGithubComDiscHighloadcupHttpapi(out *jwriter.Writer, in VisitRevision) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"visit\":"
+		out.RawString(prefix[1:])
+		(in.Visit).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"version\":"
+		out.RawString(prefix)
+		out.Uint(uint(in.Version))
+	}
+	{
+		const prefix string = ",\"updated_at\":"
+		out.RawString(prefix)
+		out.Int(int(in.UpdatedAt))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v VisitRevision) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonEada991cEncodeGithubComDiscHighloadcupHttpapi(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v VisitRevision) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonEada991cEncodeGithubComDiscHighloadcupHttpapi(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *VisitRevision) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonEada991cDecodeGithubComDiscHighloadcupHttpapi(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *VisitRevision) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonEada991cDecodeGithubComDiscHighloadcupHttpapi(l, v)
+}
+func easyjsonEada991cDecodeGithubComDiscHighloadcupHttpapi1(in *jlexer.Lexer, out *VisitHistory) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "history":
+			if in.IsNull() {
+				in.Skip()
+				out.History = nil
+			} else {
+				in.Delim('[')
+				if out.History == nil {
+					if !in.IsDelim(']') {
+						out.History = make([]VisitRevision, 0, 1)
+					} else {
+						out.History = []VisitRevision{}
+					}
+				} else {
+					out.History = (out.History)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 VisitRevision
+					if in.IsNull() {
+						in.Skip()
+					} else {
+						(v1).UnmarshalEasyJSON(in)
+					}
+					out.History = append(out.History, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonEada991cEncodeGithubComDiscHighloadcupHttpapi1(out *jwriter.Writer, in VisitHistory) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"history\":"
+		out.RawString(prefix[1:])
+		if in.History == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v2, v3 := range in.History {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				(v3).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v VisitHistory) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonEada991cEncodeGithubComDiscHighloadcupHttpapi1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v VisitHistory) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonEada991cEncodeGithubComDiscHighloadcupHttpapi1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *VisitHistory) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonEada991cDecodeGithubComDiscHighloadcupHttpapi1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *VisitHistory) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonEada991cDecodeGithubComDiscHighloadcupHttpapi1(l, v)
+}
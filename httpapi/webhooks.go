@@ -0,0 +1,347 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/disc/highloadcup/model"
+	"github.com/mailru/easyjson"
+	"github.com/valyala/fasthttp"
+)
+
+const (
+	webhookVisitCreate = "visit.create"
+	webhookVisitMark   = "visit.mark"
+
+	webhookQueueSize = 1024
+	webhookTimeout   = 5 * time.Second
+)
+
+var errWebhookQueueFull = errors.New("Delivery queue is full")
+
+//easyjson:json
+type Webhook struct {
+	Id     uint     `json:"id"`
+	Url    string   `json:"url"`
+	Events []string `json:"events"`
+}
+
+//easyjson:json
+type Webhooks struct {
+	Webhooks []Webhook `json:"webhooks"`
+}
+
+//easyjson:json
+type WebhookPayload struct {
+	Event        string          `json:"event"`
+	Seq          uint64          `json:"seq"`
+	Visit        json.RawMessage `json:"visit"`
+	PreviousMark *uint8          `json:"previous_mark,omitempty"`
+}
+
+//easyjson:json
+type WebhookDeadLetter struct {
+	Url      string          `json:"url"`
+	Payload  json.RawMessage `json:"payload"`
+	Attempts int             `json:"attempts"`
+	Error    string          `json:"error"`
+	FailedAt int             `json:"failed_at"`
+}
+
+// registeredWebhook has its own delivery queue and worker, so every
+// callback URL receives its events in order and a slow one does not hold up
+// the others.
+type registeredWebhook struct {
+	Webhook
+	deliveries chan []byte
+	removed    chan struct{}
+}
+
+// WebhookDispatcher follows the change feed and posts visit events to the
+// registered callback URLs. Failed deliveries are retried with exponential
+// backoff and end up in the dead-letter file.
+type WebhookDispatcher struct {
+	hooks  map[uint]*registeredWebhook
+	lastId uint
+	sync.RWMutex
+
+	// Attempts per delivery, the delay before the first retry and the file
+	// collecting undeliverable calls.
+	Attempts   int
+	Backoff    time.Duration
+	DeadLetter string
+	deadLock   sync.Mutex
+
+	client fasthttp.Client
+	done   chan struct{}
+}
+
+func newWebhookDispatcher() *WebhookDispatcher {
+	return &WebhookDispatcher{
+		hooks:    make(map[uint]*registeredWebhook),
+		Attempts: 5,
+		Backoff:  500 * time.Millisecond,
+		done:     make(chan struct{}),
+	}
+}
+
+func (d *WebhookDispatcher) Register(hook Webhook) (*Webhook, error) {
+	if !strings.HasPrefix(hook.Url, "http://") && !strings.HasPrefix(hook.Url, "https://") {
+		return nil, errors.New("Webhook url must be http(s)")
+	}
+	if len(hook.Events) == 0 {
+		hook.Events = []string{webhookVisitCreate, webhookVisitMark}
+	}
+	for _, event := range hook.Events {
+		if event != webhookVisitCreate && event != webhookVisitMark {
+			return nil, errors.New("Unknown webhook event " + event)
+		}
+	}
+
+	d.Lock()
+	defer d.Unlock()
+
+	d.lastId++
+	hook.Id = d.lastId
+	registered := &registeredWebhook{hook, make(chan []byte, webhookQueueSize), make(chan struct{})}
+	d.hooks[hook.Id] = registered
+	go d.work(registered)
+
+	return &hook, nil
+}
+
+func (d *WebhookDispatcher) Unregister(id uint) bool {
+	d.Lock()
+	defer d.Unlock()
+
+	hook, ok := d.hooks[id]
+	if !ok {
+		return false
+	}
+	close(hook.removed)
+	delete(d.hooks, id)
+	return true
+}
+
+func (d *WebhookDispatcher) List() []Webhook {
+	d.RLock()
+	defer d.RUnlock()
+
+	hooks := make([]Webhook, 0, len(d.hooks))
+	for _, hook := range d.hooks {
+		hooks = append(hooks, hook.Webhook)
+	}
+	sort.Slice(hooks, func(i, j int) bool { return hooks[i].Id < hooks[j].Id })
+	return hooks
+}
+
+// LoadFile registers the webhooks listed in a {"webhooks": [...]} file.
+func (d *WebhookDispatcher) LoadFile(filename string) error {
+	rawData, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	var config Webhooks
+	if err := easyjson.Unmarshal(rawData, &config); err != nil {
+		return err
+	}
+	for _, hook := range config.Webhooks {
+		if _, err := d.Register(hook); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Run dispatches the changes published after the given sequence number until
+// Stop is called.
+func (d *WebhookDispatcher) Run(feed *ChangeFeed, since uint64) {
+	for {
+		pending, ok, wait := feed.Since(since)
+		if !ok {
+			last := feed.Last()
+			log.Printf("Webhooks missed changes %d-%d, the change feed is too short", since+1, last)
+			since = last
+			continue
+		}
+		for _, change := range pending {
+			d.dispatch(change)
+			since = change.Seq
+		}
+
+		select {
+		case <-wait:
+		case <-d.done:
+			return
+		}
+	}
+}
+
+func (d *WebhookDispatcher) Stop() {
+	close(d.done)
+}
+
+func (d *WebhookDispatcher) dispatch(change Change) {
+	if change.Entity != "visit" {
+		return
+	}
+
+	payload := WebhookPayload{Seq: change.Seq, Visit: change.Data}
+	switch change.Kind {
+	case changeCreate:
+		payload.Event = webhookVisitCreate
+	case changeUpdate:
+		var visit, prevVisit model.Visit
+		if easyjson.Unmarshal(change.Data, &visit) != nil || easyjson.Unmarshal(change.Prev, &prevVisit) != nil ||
+			visit.Mark == prevVisit.Mark {
+			return
+		}
+		payload.Event = webhookVisitMark
+		payload.PreviousMark = &prevVisit.Mark
+	default:
+		return
+	}
+	body, _ := easyjson.Marshal(payload)
+
+	var targets []*registeredWebhook
+	d.RLock()
+	for _, hook := range d.hooks {
+		for _, event := range hook.Events {
+			if event == payload.Event {
+				targets = append(targets, hook)
+				break
+			}
+		}
+	}
+	d.RUnlock()
+
+	// A full queue means the callback URL is not keeping up; its events go
+	// to the dead-letter file rather than hold up the feed for the others.
+	for _, hook := range targets {
+		select {
+		case hook.deliveries <- body:
+		default:
+			d.writeDeadLetter(hook.Url, body, 0, errWebhookQueueFull)
+		}
+	}
+}
+
+func (d *WebhookDispatcher) work(hook *registeredWebhook) {
+	for {
+		select {
+		case payload := <-hook.deliveries:
+			d.deliver(hook.Url, payload)
+		case <-hook.removed:
+			return
+		case <-d.done:
+			return
+		}
+	}
+}
+
+// deliver posts the payload, at least once even with fewer Attempts set, so
+// a failed delivery always has an error for the dead letter.
+func (d *WebhookDispatcher) deliver(url string, payload []byte) {
+	var (
+		err      error
+		attempt  int
+		attempts = d.Attempts
+		backoff  = d.Backoff
+	)
+	if attempts < 1 {
+		attempts = 1
+	}
+	for attempt = 1; attempt <= attempts; attempt++ {
+		if err = d.post(url, payload); err == nil {
+			return
+		}
+		if attempt == attempts {
+			break
+		}
+		select {
+		case <-time.After(backoff):
+			backoff *= 2
+		case <-d.done:
+			d.writeDeadLetter(url, payload, attempt, err)
+			return
+		}
+	}
+	d.writeDeadLetter(url, payload, attempts, err)
+}
+
+func (d *WebhookDispatcher) post(url string, payload []byte) error {
+	req := fasthttp.AcquireRequest()
+	resp := fasthttp.AcquireResponse()
+	defer fasthttp.ReleaseRequest(req)
+	defer fasthttp.ReleaseResponse(resp)
+
+	req.SetRequestURI(url)
+	req.Header.SetMethod("POST")
+	req.Header.SetContentType("application/json")
+	req.SetBody(payload)
+	if err := d.client.DoTimeout(req, resp, webhookTimeout); err != nil {
+		return err
+	}
+	if status := resp.StatusCode(); status < 200 || status >= 300 {
+		return fmt.Errorf("Unexpected status %d", status)
+	}
+	return nil
+}
+
+func (d *WebhookDispatcher) writeDeadLetter(url string, payload []byte, attempts int, err error) {
+	log.Printf("Webhook delivery to %s failed after %d attempts: %s", url, attempts, err)
+	if d.DeadLetter == "" {
+		return
+	}
+
+	line, _ := easyjson.Marshal(WebhookDeadLetter{url, payload, attempts, err.Error(), revisionTime()})
+	d.deadLock.Lock()
+	defer d.deadLock.Unlock()
+
+	file, openErr := os.OpenFile(d.DeadLetter, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if openErr != nil {
+		log.Printf("Can't open webhook dead-letter file: %s", openErr)
+		return
+	}
+	defer file.Close()
+	file.Write(append(line, '\n'))
+}
+
+func (srv *Server) webhooksRequestHandler(ctx *fasthttp.RequestCtx) {
+	if ctx.IsPost() {
+		var hook Webhook
+		if err := easyjson.Unmarshal(ctx.PostBody(), &hook); err != nil {
+			srv.badRequest(ctx, err)
+			return
+		}
+		registered, err := srv.Webhooks.Register(hook)
+		if err != nil {
+			srv.badRequest(ctx, err)
+			return
+		}
+		response, _ := easyjson.Marshal(registered)
+		ctx.Success("application/json", response)
+		return
+	}
+
+	response, _ := easyjson.Marshal(Webhooks{srv.Webhooks.List()})
+	ctx.Success("application/json", response)
+}
+
+func (srv *Server) webhookRequestHandler(ctx *fasthttp.RequestCtx, id []byte) {
+	hookId, err := strconv.ParseUint(string(id), 10, 32)
+	if err != nil || !ctx.IsDelete() || !srv.Webhooks.Unregister(uint(hookId)) {
+		srv.notFound(ctx)
+		return
+	}
+	ctx.Success("application/json", []byte("{}"))
+}
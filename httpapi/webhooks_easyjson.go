@@ -0,0 +1,454 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package httpapi
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson728cb8f2DecodeGithubComDiscHighloadcupHttpapi(in *jlexer.Lexer, out *Webhooks) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "webhooks":
+			if in.IsNull() {
+				in.Skip()
+				out.Webhooks = nil
+			} else {
+				in.Delim('[')
+				if out.Webhooks == nil {
+					if !in.IsDelim(']') {
+						out.Webhooks = make([]Webhook, 0, 1)
+					} else {
+						out.Webhooks = []Webhook{}
+					}
+				} else {
+					out.Webhooks = (out.Webhooks)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 Webhook
+					if in.IsNull() {
+						in.Skip()
+					} else {
+						(v1).UnmarshalEasyJSON(in)
+					}
+					out.Webhooks = append(out.Webhooks, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson728cb8f2EncodeGithubComDiscHighloadcupHttpapi(out *jwriter.Writer, in Webhooks) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"webhooks\":"
+		out.RawString(prefix[1:])
+		if in.Webhooks == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v2, v3 := range in.Webhooks {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				(v3).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Webhooks) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson728cb8f2EncodeGithubComDiscHighloadcupHttpapi(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Webhooks) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson728cb8f2EncodeGithubComDiscHighloadcupHttpapi(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Webhooks) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson728cb8f2DecodeGithubComDiscHighloadcupHttpapi(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Webhooks) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson728cb8f2DecodeGithubComDiscHighloadcupHttpapi(l, v)
+}
+func easyjson728cb8f2DecodeGithubComDiscHighloadcupHttpapi1(in *jlexer.Lexer, out *WebhookPayload) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "event":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Event = string(in.String())
+			}
+		case "seq":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Seq = uint64(in.Uint64())
+			}
+		case "visit":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				if data := in.Raw(); in.Ok() {
+					in.AddError((out.Visit).UnmarshalJSON(data))
+				}
+			}
+		case "previous_mark":
+			if in.IsNull() {
+				in.Skip()
+				out.PreviousMark = nil
+			} else {
+				if out.PreviousMark == nil {
+					out.PreviousMark = new(uint8)
+				}
+				if in.IsNull() {
+					in.Skip()
+				} else {
+					*out.PreviousMark = uint8(in.Uint8())
+				}
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson728cb8f2EncodeGithubComDiscHighloadcupHttpapi1(out *jwriter.Writer, in WebhookPayload) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"event\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Event))
+	}
+	{
+		const prefix string = ",\"seq\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.Seq))
+	}
+	{
+		const prefix string = ",\"visit\":"
+		out.RawString(prefix)
+		out.Raw((in.Visit).MarshalJSON())
+	}
+	if in.PreviousMark != nil {
+		const prefix string = ",\"previous_mark\":"
+		out.RawString(prefix)
+		out.Uint8(uint8(*in.PreviousMark))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v WebhookPayload) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson728cb8f2EncodeGithubComDiscHighloadcupHttpapi1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v WebhookPayload) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson728cb8f2EncodeGithubComDiscHighloadcupHttpapi1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *WebhookPayload) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson728cb8f2DecodeGithubComDiscHighloadcupHttpapi1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *WebhookPayload) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson728cb8f2DecodeGithubComDiscHighloadcupHttpapi1(l, v)
+}
+func easyjson728cb8f2DecodeGithubComDiscHighloadcupHttpapi2(in *jlexer.Lexer, out *WebhookDeadLetter) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "url":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Url = string(in.String())
+			}
+		case "payload":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				if data := in.Raw(); in.Ok() {
+					in.AddError((out.Payload).UnmarshalJSON(data))
+				}
+			}
+		case "attempts":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Attempts = int(in.Int())
+			}
+		case "error":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Error = string(in.String())
+			}
+		case "failed_at":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.FailedAt = int(in.Int())
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson728cb8f2EncodeGithubComDiscHighloadcupHttpapi2(out *jwriter.Writer, in WebhookDeadLetter) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"url\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Url))
+	}
+	{
+		const prefix string = ",\"payload\":"
+		out.RawString(prefix)
+		out.Raw((in.Payload).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"attempts\":"
+		out.RawString(prefix)
+		out.Int(int(in.Attempts))
+	}
+	{
+		const prefix string = ",\"error\":"
+		out.RawString(prefix)
+		out.String(string(in.Error))
+	}
+	{
+		const prefix string = ",\"failed_at\":"
+		out.RawString(prefix)
+		out.Int(int(in.FailedAt))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v WebhookDeadLetter) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson728cb8f2EncodeGithubComDiscHighloadcupHttpapi2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v WebhookDeadLetter) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson728cb8f2EncodeGithubComDiscHighloadcupHttpapi2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *WebhookDeadLetter) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson728cb8f2DecodeGithubComDiscHighloadcupHttpapi2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *WebhookDeadLetter) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson728cb8f2DecodeGithubComDiscHighloadcupHttpapi2(l, v)
+}
+func easyjson728cb8f2DecodeGithubComDiscHighloadcupHttpapi3(in *jlexer.Lexer, out *Webhook) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "id":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Id = uint(in.Uint())
+			}
+		case "url":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Url = string(in.String())
+			}
+		case "events":
+			if in.IsNull() {
+				in.Skip()
+				out.Events = nil
+			} else {
+				in.Delim('[')
+				if out.Events == nil {
+					if !in.IsDelim(']') {
+						out.Events = make([]string, 0, 4)
+					} else {
+						out.Events = []string{}
+					}
+				} else {
+					out.Events = (out.Events)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v4 string
+					if in.IsNull() {
+						in.Skip()
+					} else {
+						v4 = string(in.String())
+					}
+					out.Events = append(out.Events, v4)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson728cb8f2EncodeGithubComDiscHighloadcupHttpapi3(out *jwriter.Writer, in Webhook) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.Uint(uint(in.Id))
+	}
+	{
+		const prefix string = ",\"url\":"
+		out.RawString(prefix)
+		out.String(string(in.Url))
+	}
+	{
+		const prefix string = ",\"events\":"
+		out.RawString(prefix)
+		if in.Events == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v5, v6 := range in.Events {
+				if v5 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v6))
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Webhook) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson728cb8f2EncodeGithubComDiscHighloadcupHttpapi3(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Webhook) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson728cb8f2EncodeGithubComDiscHighloadcupHttpapi3(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Webhook) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson728cb8f2DecodeGithubComDiscHighloadcupHttpapi3(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Webhook) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson728cb8f2DecodeGithubComDiscHighloadcupHttpapi3(l, v)
+}
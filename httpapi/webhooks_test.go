@@ -0,0 +1,206 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/disc/highloadcup/model"
+)
+
+func newWebhookReceiver(t *testing.T, failures int32) (*httptest.Server, chan WebhookPayload) {
+	var (
+		received = make(chan WebhookPayload, 16)
+		calls    int32
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) <= failures {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		var payload WebhookPayload
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Error(err)
+		}
+		received <- payload
+	}))
+	return server, received
+}
+
+func waitWebhookPayload(t *testing.T, received chan WebhookPayload) WebhookPayload {
+	select {
+	case payload := <-received:
+		return payload
+	case <-time.After(5 * time.Second):
+		t.Fatal("webhook was not delivered")
+	}
+	return WebhookPayload{}
+}
+
+func TestWebhookVisitEvents(t *testing.T) {
+	server, received := newWebhookReceiver(t, 0)
+	defer server.Close()
+
+	feed := newChangeFeed(100)
+	dispatcher := newWebhookDispatcher()
+	if _, err := dispatcher.Register(Webhook{Url: server.URL}); err != nil {
+		t.Fatal(err)
+	}
+	go dispatcher.Run(feed, 0)
+	defer dispatcher.Stop()
+
+	visit := model.Visit{Id: 1, Location: 2, User: 3, Visited_at: 1000000000, Mark: 2}
+	feed.Publish(changeCreate, "visit", uint(visit.Id), 1, visit, nil)
+	feed.Publish(changeUpdate, "user", 3, 2, model.User{Id: 3}, model.User{Id: 3})
+
+	updated := visit
+	updated.Visited_at++
+	feed.Publish(changeUpdate, "visit", uint(visit.Id), 2, updated, visit)
+
+	marked := updated
+	marked.Mark = 5
+	feed.Publish(changeUpdate, "visit", uint(visit.Id), 3, marked, updated)
+
+	if payload := waitWebhookPayload(t, received); payload.Event != webhookVisitCreate || payload.Seq != 1 {
+		t.Errorf("unexpected first payload %+v", payload)
+	}
+	payload := waitWebhookPayload(t, received)
+	if payload.Event != webhookVisitMark || payload.Seq != 4 || payload.PreviousMark == nil || *payload.PreviousMark != 2 {
+		t.Errorf("unexpected second payload %+v", payload)
+	}
+	var visitData model.Visit
+	if err := json.Unmarshal(payload.Visit, &visitData); err != nil || visitData.Mark != 5 {
+		t.Errorf("unexpected visit %s", payload.Visit)
+	}
+}
+
+func TestWebhookRetriesAndDeadLetter(t *testing.T) {
+	flaky, received := newWebhookReceiver(t, 2)
+	defer flaky.Close()
+	broken, _ := newWebhookReceiver(t, 1000)
+	defer broken.Close()
+
+	dir, err := ioutil.TempDir("", "webhooks")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	feed := newChangeFeed(100)
+	dispatcher := newWebhookDispatcher()
+	dispatcher.Attempts = 3
+	dispatcher.Backoff = 10 * time.Millisecond
+	dispatcher.DeadLetter = filepath.Join(dir, "dead.jsonl")
+	dispatcher.Register(Webhook{Url: flaky.URL, Events: []string{webhookVisitCreate}})
+	dispatcher.Register(Webhook{Url: broken.URL, Events: []string{webhookVisitCreate}})
+	if _, err := dispatcher.Register(Webhook{Url: broken.URL, Events: []string{"user.create"}}); err == nil {
+		t.Error("unknown event was accepted")
+	}
+	go dispatcher.Run(feed, 0)
+	defer dispatcher.Stop()
+
+	feed.Publish(changeCreate, "visit", 1, 1, model.Visit{Id: 1, Location: 2, User: 3, Mark: 4}, nil)
+
+	if payload := waitWebhookPayload(t, received); payload.Event != webhookVisitCreate {
+		t.Errorf("unexpected payload %+v", payload)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		contents, _ := ioutil.ReadFile(dispatcher.DeadLetter)
+		if len(contents) > 0 {
+			var letter WebhookDeadLetter
+			if err := json.Unmarshal(contents, &letter); err != nil {
+				t.Fatal(err)
+			}
+			if letter.Url != broken.URL || letter.Attempts != 3 || !strings.Contains(letter.Error, "500") {
+				t.Errorf("unexpected dead letter %s", contents)
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("dead letter was not written")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// With no attempts set a delivery is still tried once, and its error goes to
+// the dead letter.
+func TestWebhookNoAttempts(t *testing.T) {
+	broken, _ := newWebhookReceiver(t, 1000)
+	defer broken.Close()
+
+	dir, err := ioutil.TempDir("", "webhooks")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	dispatcher := newWebhookDispatcher()
+	dispatcher.Attempts = 0
+	dispatcher.DeadLetter = filepath.Join(dir, "dead.jsonl")
+	dispatcher.deliver(broken.URL, []byte("{}"))
+
+	contents, _ := ioutil.ReadFile(dispatcher.DeadLetter)
+	var letter WebhookDeadLetter
+	if err := json.Unmarshal(contents, &letter); err != nil {
+		t.Fatal(err)
+	}
+	if letter.Attempts != 1 || !strings.Contains(letter.Error, "500") {
+		t.Errorf("unexpected dead letter %s", contents)
+	}
+}
+
+func TestWebhookFullQueue(t *testing.T) {
+	release := make(chan struct{})
+	stuck := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer stuck.Close()
+	defer close(release)
+
+	dir, err := ioutil.TempDir("", "webhooks")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	feed := newChangeFeed(2 * webhookQueueSize)
+	dispatcher := newWebhookDispatcher()
+	dispatcher.Attempts = 1
+	dispatcher.DeadLetter = filepath.Join(dir, "dead.jsonl")
+	dispatcher.Register(Webhook{Url: stuck.URL, Events: []string{webhookVisitCreate}})
+	go dispatcher.Run(feed, 0)
+	defer dispatcher.Stop()
+
+	// The worker holds the first event, the queue takes the next ones and the
+	// rest overflow to the dead-letter file without blocking the feed.
+	const overflow = 3
+	for id := uint(1); id <= webhookQueueSize+1+overflow; id++ {
+		feed.Publish(changeCreate, "visit", id, 1, model.Visit{Id: uint32(id), Location: 2, User: 3, Mark: 4}, nil)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		contents, _ := ioutil.ReadFile(dispatcher.DeadLetter)
+		if lines := strings.Split(strings.TrimSpace(string(contents)), "\n"); len(contents) > 0 && len(lines) >= overflow {
+			var letter WebhookDeadLetter
+			if err := json.Unmarshal([]byte(lines[0]), &letter); err != nil {
+				t.Fatal(err)
+			}
+			if letter.Url != stuck.URL || letter.Error != errWebhookQueueFull.Error() {
+				t.Errorf("unexpected dead letter %s", lines[0])
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("got dead letters %q, want %d", contents, overflow)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
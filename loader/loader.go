@@ -0,0 +1,163 @@
+// Package loader fills a store from the JSON files of the contest data set.
+package loader
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/disc/highloadcup/model"
+	"github.com/disc/highloadcup/store"
+	"github.com/mailru/easyjson"
+)
+
+//easyjson:json
+type Locations struct {
+	Locations []model.Location `json:"locations"`
+}
+
+//easyjson:json
+type Users struct {
+	Users []model.User `json:"users"`
+}
+
+//easyjson:json
+type Visits struct {
+	Visits []model.Visit `json:"visits"`
+}
+
+func parseLocations(s *store.Store, fileBytes []byte) (int, error) {
+	var locations Locations
+	if err := easyjson.Unmarshal(fileBytes, &locations); err != nil {
+		return 0, err
+	}
+
+	skipped := 0
+	for _, location := range locations.Locations {
+		if model.ValidateLocation(&location) != nil {
+			skipped++
+			continue
+		}
+		if version, _ := s.Locations.Update(location); version == 0 {
+			return skipped, fmt.Errorf("location %d: %s", location.Id, store.ErrWriteFailed)
+		}
+	}
+	return skipped, nil
+}
+
+func parseVisits(s *store.Store, fileBytes []byte) (int, error) {
+	var visits Visits
+	if err := easyjson.Unmarshal(fileBytes, &visits); err != nil {
+		return 0, err
+	}
+
+	skipped := 0
+	for _, visit := range visits.Visits {
+		if model.ValidateVisit(&visit) != nil {
+			skipped++
+			continue
+		}
+		if version, _ := s.Visits.Update(visit); version == 0 {
+			return skipped, fmt.Errorf("visit %d: %s", visit.Id, store.ErrWriteFailed)
+		}
+	}
+	return skipped, nil
+}
+
+func parseUsers(s *store.Store, fileBytes []byte) (int, error) {
+	var users Users
+	if err := easyjson.Unmarshal(fileBytes, &users); err != nil {
+		return 0, err
+	}
+
+	skipped := 0
+	for _, user := range users.Users {
+		if s.Users.Validate(&user) != nil {
+			skipped++
+			continue
+		}
+		if version, _ := s.Users.Update(user); version == 0 {
+			return skipped, fmt.Errorf("user %d: %s", user.Id, store.ErrWriteFailed)
+		}
+	}
+	return skipped, nil
+}
+
+// ParseOptions reads the time ages are counted from, if the file exists.
+func ParseOptions(s *store.Store, filename string) error {
+	file, err := os.Open(filename)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	line, _, err := bufio.NewReader(file).ReadLine()
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	now, err := strconv.Atoi(strings.TrimSpace(string(line)))
+	if err != nil {
+		return fmt.Errorf("%s: %s", filename, err)
+	}
+	s.Now = now
+	return nil
+}
+
+// ParseFile loads a users_, locations_ or visits_ file of the data set or
+// its options.txt, and returns the number of invalid entities it skipped.
+func ParseFile(s *store.Store, filename string) (int, error) {
+	if strings.LastIndex(filename, "options.txt") != -1 {
+		return 0, ParseOptions(s, filename)
+	}
+
+	rawData, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return 0, err
+	}
+
+	var skipped int
+	if strings.LastIndex(filename, "users_") != -1 {
+		skipped, err = parseUsers(s, rawData)
+	} else if strings.LastIndex(filename, "locations_") != -1 {
+		skipped, err = parseLocations(s, rawData)
+	} else if strings.LastIndex(filename, "visits_") != -1 {
+		skipped, err = parseVisits(s, rawData)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("%s: %s", filename, err)
+	}
+	return skipped, nil
+}
+
+// ParseDataDir loads every file of the data set in dirPath and returns the
+// number of invalid entities skipped.
+func ParseDataDir(s *store.Store, dirPath string) (int, error) {
+	files, err := ioutil.ReadDir(dirPath)
+	if err != nil {
+		return 0, err
+	}
+
+	skipped := 0
+	for _, f := range files {
+		if f.IsDir() {
+			continue
+		}
+		n, err := ParseFile(s, filepath.Join(dirPath, f.Name()))
+		if err != nil {
+			return skipped, err
+		}
+		skipped += n
+	}
+	return skipped, nil
+}
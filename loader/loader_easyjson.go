@@ -0,0 +1,311 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package loader
+
+import (
+	json "encoding/json"
+	model "github.com/disc/highloadcup/model"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjsonB5131bbdDecodeGithubComDiscHighloadcupLoader(in *jlexer.Lexer, out *Visits) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "visits":
+			if in.IsNull() {
+				in.Skip()
+				out.Visits = nil
+			} else {
+				in.Delim('[')
+				if out.Visits == nil {
+					if !in.IsDelim(']') {
+						out.Visits = make([]model.Visit, 0, 2)
+					} else {
+						out.Visits = []model.Visit{}
+					}
+				} else {
+					out.Visits = (out.Visits)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 model.Visit
+					if in.IsNull() {
+						in.Skip()
+					} else {
+						(v1).UnmarshalEasyJSON(in)
+					}
+					out.Visits = append(out.Visits, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonB5131bbdEncodeGithubComDiscHighloadcupLoader(out *jwriter.Writer, in Visits) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"visits\":"
+		out.RawString(prefix[1:])
+		if in.Visits == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v2, v3 := range in.Visits {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				(v3).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Visits) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonB5131bbdEncodeGithubComDiscHighloadcupLoader(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Visits) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonB5131bbdEncodeGithubComDiscHighloadcupLoader(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Visits) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonB5131bbdDecodeGithubComDiscHighloadcupLoader(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Visits) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonB5131bbdDecodeGithubComDiscHighloadcupLoader(l, v)
+}
+func easyjsonB5131bbdDecodeGithubComDiscHighloadcupLoader1(in *jlexer.Lexer, out *Users) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "users":
+			if in.IsNull() {
+				in.Skip()
+				out.Users = nil
+			} else {
+				in.Delim('[')
+				if out.Users == nil {
+					if !in.IsDelim(']') {
+						out.Users = make([]model.User, 0, 0)
+					} else {
+						out.Users = []model.User{}
+					}
+				} else {
+					out.Users = (out.Users)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v4 model.User
+					if in.IsNull() {
+						in.Skip()
+					} else {
+						(v4).UnmarshalEasyJSON(in)
+					}
+					out.Users = append(out.Users, v4)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonB5131bbdEncodeGithubComDiscHighloadcupLoader1(out *jwriter.Writer, in Users) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"users\":"
+		out.RawString(prefix[1:])
+		if in.Users == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v5, v6 := range in.Users {
+				if v5 > 0 {
+					out.RawByte(',')
+				}
+				(v6).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Users) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonB5131bbdEncodeGithubComDiscHighloadcupLoader1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Users) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonB5131bbdEncodeGithubComDiscHighloadcupLoader1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Users) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonB5131bbdDecodeGithubComDiscHighloadcupLoader1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Users) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonB5131bbdDecodeGithubComDiscHighloadcupLoader1(l, v)
+}
+func easyjsonB5131bbdDecodeGithubComDiscHighloadcupLoader2(in *jlexer.Lexer, out *Locations) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "locations":
+			if in.IsNull() {
+				in.Skip()
+				out.Locations = nil
+			} else {
+				in.Delim('[')
+				if out.Locations == nil {
+					if !in.IsDelim(']') {
+						out.Locations = make([]model.Location, 0, 0)
+					} else {
+						out.Locations = []model.Location{}
+					}
+				} else {
+					out.Locations = (out.Locations)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v7 model.Location
+					if in.IsNull() {
+						in.Skip()
+					} else {
+						(v7).UnmarshalEasyJSON(in)
+					}
+					out.Locations = append(out.Locations, v7)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonB5131bbdEncodeGithubComDiscHighloadcupLoader2(out *jwriter.Writer, in Locations) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"locations\":"
+		out.RawString(prefix[1:])
+		if in.Locations == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v8, v9 := range in.Locations {
+				if v8 > 0 {
+					out.RawByte(',')
+				}
+				(v9).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Locations) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonB5131bbdEncodeGithubComDiscHighloadcupLoader2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Locations) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonB5131bbdEncodeGithubComDiscHighloadcupLoader2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Locations) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonB5131bbdDecodeGithubComDiscHighloadcupLoader2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Locations) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonB5131bbdDecodeGithubComDiscHighloadcupLoader2(l, v)
+}
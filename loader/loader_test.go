@@ -0,0 +1,87 @@
+package loader
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/disc/highloadcup/store"
+	"github.com/mailru/easyjson"
+)
+
+func TestWorkingDirectory(t *testing.T) {
+	wd, _ := os.Getwd()
+	t.Log(wd)
+
+	s := store.NewStore(16)
+	for _, filename := range []string{"../data/users_1.json", "../data/locations_1.json", "../data/visits_1.json"} {
+		if _, err := ParseFile(s, filename); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	dir, err := ioutil.TempDir("", "loader")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	write := func(name string, contents string) {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("users_1.json", `{"users": [{"id": 1, "email": "a@example.com", "first_name": "A", "last_name": "B", "gender": "m", "birth_date": 0},`+
+		`{"id": 2, "email": "b@example.com", "first_name": "A", "last_name": "B", "gender": "x", "birth_date": 0}]}`)
+	write("options.txt", "1500000000\n")
+
+	s := store.NewStore(0)
+	if skipped, err := ParseDataDir(s, dir); err != nil || skipped != 1 || s.Users.Len() != 1 || s.Now != 1500000000 {
+		t.Errorf("got %d skipped, %v, %d users and now %d", skipped, err, s.Users.Len(), s.Now)
+	}
+
+	// A file store whose files are closed refuses every write.
+	closed, err := store.OpenFileStore(filepath.Join(dir, "store"), store.NewStore(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	closed.Close()
+	if _, err := ParseFile(closed, filepath.Join(dir, "users_1.json")); err == nil || !strings.Contains(err.Error(), "user 1") {
+		t.Errorf("got %v for a failed write", err)
+	}
+	os.RemoveAll(filepath.Join(dir, "store"))
+
+	write("visits_1.json", `{"visits": [`)
+	if _, err := ParseDataDir(store.NewStore(0), dir); err == nil || !strings.Contains(err.Error(), "visits_1.json") {
+		t.Errorf("got %v for a broken file", err)
+	}
+	write("options.txt", "soon")
+	if err := ParseOptions(store.NewStore(0), filepath.Join(dir, "options.txt")); err == nil {
+		t.Error("accepted a broken options.txt")
+	}
+	if err := ParseOptions(store.NewStore(0), filepath.Join(dir, "missing.txt")); err != nil {
+		t.Errorf("got %v for a missing options.txt", err)
+	}
+	if _, err := ParseDataDir(store.NewStore(0), filepath.Join(dir, "missing")); err == nil {
+		t.Error("read a missing directory")
+	}
+}
+
+func BenchmarkLoadVisits(b *testing.B) {
+	rawData, err := ioutil.ReadFile("../data/visits_1.json")
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		var visits Visits
+		if err := easyjson.Unmarshal(rawData, &visits); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
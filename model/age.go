@@ -0,0 +1,15 @@
+package model
+
+import "math"
+
+// TimestampByAge returns the latest birth date of someone of the given age
+// at the unix time now.
+func TimestampByAge(age *int, now int) int {
+	return now - (*age)*int(math.Floor(365.25*24*60*60))
+}
+
+// AgeByTimestamp returns the age at the unix time now of someone born at
+// birthDate.
+func AgeByTimestamp(birthDate int, now int) int {
+	return int(math.Floor(float64(now-birthDate) / math.Floor(365.25*24*60*60)))
+}
@@ -0,0 +1,13 @@
+package model
+
+//easyjson:json
+type Location struct {
+	Id       uint   `json:"id"`
+	Place    string `json:"place"`
+	Country  string `json:"country"`
+	City     string `json:"city"`
+	Distance uint   `json:"distance"`
+
+	Version   uint `json:"-"`
+	UpdatedAt int  `json:"-"`
+}
@@ -0,0 +1,128 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package model
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson14b80819DecodeGithubComDiscHighloadcupModel(in *jlexer.Lexer, out *Location) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "id":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Id = uint(in.Uint())
+			}
+		case "place":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Place = string(in.String())
+			}
+		case "country":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Country = string(in.String())
+			}
+		case "city":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.City = string(in.String())
+			}
+		case "distance":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Distance = uint(in.Uint())
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson14b80819EncodeGithubComDiscHighloadcupModel(out *jwriter.Writer, in Location) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.Uint(uint(in.Id))
+	}
+	{
+		const prefix string = ",\"place\":"
+		out.RawString(prefix)
+		out.String(string(in.Place))
+	}
+	{
+		const prefix string = ",\"country\":"
+		out.RawString(prefix)
+		out.String(string(in.Country))
+	}
+	{
+		const prefix string = ",\"city\":"
+		out.RawString(prefix)
+		out.String(string(in.City))
+	}
+	{
+		const prefix string = ",\"distance\":"
+		out.RawString(prefix)
+		out.Uint(uint(in.Distance))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Location) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson14b80819EncodeGithubComDiscHighloadcupModel(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Location) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson14b80819EncodeGithubComDiscHighloadcupModel(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Location) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson14b80819DecodeGithubComDiscHighloadcupModel(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Location) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson14b80819DecodeGithubComDiscHighloadcupModel(l, v)
+}
@@ -0,0 +1,201 @@
+package model
+
+import (
+	"strconv"
+
+	"github.com/mailru/easyjson/jlexer"
+)
+
+// UserPatch, LocationPatch and VisitPatch are partial updates decoded from
+// the request body: a field is non-nil only if the body has it. Entity
+// fields can't be unset, so a null value fails the decoding, as do unknown
+// fields, values of the wrong type and numbers that aren't integers.
+type UserPatch struct {
+	Email      *string
+	First_name *string
+	Last_name  *string
+	Gender     *string
+	Birth_date *int
+}
+
+type LocationPatch struct {
+	Place    *string
+	Country  *string
+	City     *string
+	Distance *uint
+}
+
+type VisitPatch struct {
+	Location   *uint32
+	User       *uint32
+	Visited_at *int32
+	Mark       *uint8
+}
+
+// PatchError names the field of a partial update that can't be applied.
+type PatchError struct {
+	Field   string
+	Message string
+}
+
+func (e *PatchError) Error() string {
+	return e.Field + " " + e.Message
+}
+
+// The fields of every patch and the JSON token each of them takes.
+var (
+	userPatchKinds = map[string]jlexer.TokenKind{
+		"email":      jlexer.TokenString,
+		"first_name": jlexer.TokenString,
+		"last_name":  jlexer.TokenString,
+		"gender":     jlexer.TokenString,
+		"birth_date": jlexer.TokenNumber,
+	}
+	locationPatchKinds = map[string]jlexer.TokenKind{
+		"place":    jlexer.TokenString,
+		"country":  jlexer.TokenString,
+		"city":     jlexer.TokenString,
+		"distance": jlexer.TokenNumber,
+	}
+	visitPatchKinds = map[string]jlexer.TokenKind{
+		"location":   jlexer.TokenNumber,
+		"user":       jlexer.TokenNumber,
+		"visited_at": jlexer.TokenNumber,
+		"mark":       jlexer.TokenNumber,
+	}
+)
+
+// decodePatch walks the fields of a JSON object, checks that each one is
+// known and holds a token of its kind, and hands it to decodeField, which
+// has to consume the value.
+func decodePatch(in *jlexer.Lexer, kinds map[string]jlexer.TokenKind, decodeField func(key string) error) {
+	isTopLevel := in.IsStart()
+	in.Delim('{')
+	for in.Ok() && !in.IsDelim('}') {
+		key := in.String()
+		in.WantColon()
+		if !in.Ok() {
+			return
+		}
+
+		kind, known := kinds[key]
+		switch {
+		case !known:
+			in.AddError(&PatchError{key, "is unknown"})
+		case in.IsNull():
+			in.AddError(&PatchError{key, "can't be null"})
+		case in.CurrentToken() != kind && kind == jlexer.TokenString:
+			in.AddError(&PatchError{key, "must be a string"})
+		case in.CurrentToken() != kind:
+			in.AddError(&PatchError{key, "must be an integer"})
+		default:
+			if err := decodeField(key); err != nil {
+				in.AddError(err)
+			}
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+
+// patchInt and patchUint read a number that has to fit into bitSize bits,
+// so the caller can narrow it to the type of the field.
+func patchInt(in *jlexer.Lexer, key string, bitSize int) (int64, error) {
+	value, err := strconv.ParseInt(string(in.JsonNumber()), 10, bitSize)
+	if numErr, ok := err.(*strconv.NumError); ok && numErr.Err == strconv.ErrRange {
+		return 0, &PatchError{key, "is out of range"}
+	}
+	if err != nil {
+		return 0, &PatchError{key, "must be an integer"}
+	}
+	return value, nil
+}
+
+func patchUint(in *jlexer.Lexer, key string, bitSize int) (uint64, error) {
+	value, err := strconv.ParseUint(string(in.JsonNumber()), 10, bitSize)
+	if numErr, ok := err.(*strconv.NumError); ok && numErr.Err == strconv.ErrRange {
+		return 0, &PatchError{key, "is out of range"}
+	}
+	if err != nil {
+		return 0, &PatchError{key, "must be a non-negative integer"}
+	}
+	return value, nil
+}
+
+func (p *UserPatch) UnmarshalEasyJSON(in *jlexer.Lexer) {
+	decodePatch(in, userPatchKinds, func(key string) (err error) {
+		switch key {
+		case "email":
+			email := in.String()
+			p.Email = &email
+		case "first_name":
+			firstName := in.String()
+			p.First_name = &firstName
+		case "last_name":
+			lastName := in.String()
+			p.Last_name = &lastName
+		case "gender":
+			gender := in.String()
+			p.Gender = &gender
+		case "birth_date":
+			var birthDate int64
+			birthDate, err = patchInt(in, key, 0)
+			p.Birth_date = new(int)
+			*p.Birth_date = int(birthDate)
+		}
+		return
+	})
+}
+
+func (p *LocationPatch) UnmarshalEasyJSON(in *jlexer.Lexer) {
+	decodePatch(in, locationPatchKinds, func(key string) (err error) {
+		switch key {
+		case "place":
+			place := in.String()
+			p.Place = &place
+		case "country":
+			country := in.String()
+			p.Country = &country
+		case "city":
+			city := in.String()
+			p.City = &city
+		case "distance":
+			var distance uint64
+			distance, err = patchUint(in, key, 0)
+			p.Distance = new(uint)
+			*p.Distance = uint(distance)
+		}
+		return
+	})
+}
+
+func (p *VisitPatch) UnmarshalEasyJSON(in *jlexer.Lexer) {
+	decodePatch(in, visitPatchKinds, func(key string) (err error) {
+		switch key {
+		case "location":
+			var location uint64
+			location, err = patchUint(in, key, 32)
+			p.Location = new(uint32)
+			*p.Location = uint32(location)
+		case "user":
+			var user uint64
+			user, err = patchUint(in, key, 32)
+			p.User = new(uint32)
+			*p.User = uint32(user)
+		case "visited_at":
+			var visitedAt int64
+			visitedAt, err = patchInt(in, key, 32)
+			p.Visited_at = new(int32)
+			*p.Visited_at = int32(visitedAt)
+		case "mark":
+			var mark uint64
+			mark, err = patchUint(in, key, 8)
+			p.Mark = new(uint8)
+			*p.Mark = uint8(mark)
+		}
+		return
+	})
+}
@@ -0,0 +1,54 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/mailru/easyjson"
+)
+
+func TestVisitPatch(t *testing.T) {
+	tests := []struct {
+		body  string
+		field string
+	}{
+		{`{}`, ""},
+		{`{"mark": 5, "visited_at": -100}`, ""},
+		{`{"mark": "5"}`, "mark"},
+		{`{"mark": null}`, "mark"},
+		{`{"mark": 4.5}`, "mark"},
+		{`{"mark": 1e1}`, "mark"},
+		{`{"location": -1}`, "location"},
+		{`{"user": true}`, "user"},
+		{`{"mark": 1, "id": 2}`, "id"},
+		{`{"mark": 255}`, ""},
+		{`{"mark": 256}`, "mark"},
+		{`{"location": 4294967296}`, "location"},
+		{`{"visited_at": -2147483649}`, "visited_at"},
+	}
+	for _, test := range tests {
+		var patch VisitPatch
+		err := easyjson.Unmarshal([]byte(test.body), &patch)
+		if test.field == "" {
+			if err != nil {
+				t.Errorf("%s: unexpected error %v", test.body, err)
+			}
+			continue
+		}
+		if patchErr, ok := err.(*PatchError); !ok || patchErr.Field != test.field {
+			t.Errorf("%s: got error %v, want one about %s", test.body, err, test.field)
+		}
+	}
+
+	for _, body := range []string{``, `null`, `[]`, `{"mark": 1`, `{"mark": 1} {}`} {
+		var patch VisitPatch
+		if err := easyjson.Unmarshal([]byte(body), &patch); err == nil {
+			t.Errorf("%q: expected an error", body)
+		}
+	}
+
+	var patch VisitPatch
+	easyjson.Unmarshal([]byte(`{"mark": 0}`), &patch)
+	if patch.Mark == nil || *patch.Mark != 0 || patch.User != nil {
+		t.Errorf("unexpected patch %+v", patch)
+	}
+}
@@ -0,0 +1,16 @@
+// Package model holds the entities of the travels API, their partial
+// updates and the rules they have to follow.
+package model
+
+//easyjson:json
+type User struct {
+	Id         uint   `json:"id"`
+	Email      string `json:"email"`
+	First_name string `json:"first_name"`
+	Last_name  string `json:"last_name"`
+	Gender     string `json:"gender"`
+	Birth_date int    `json:"birth_date"`
+
+	Version   uint `json:"-"`
+	UpdatedAt int  `json:"-"`
+}
@@ -0,0 +1,145 @@
+package model
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Bounds of the dates from the contest spec, from 01.01.1930 to 01.01.1999
+// for birth dates and from 01.01.2000 to 01.01.2015 for visits.
+const (
+	MinBirthDate = -1262304000
+	MaxBirthDate = 915148800
+	MinVisitedAt = 946684800
+	MaxVisitedAt = 1420070400
+)
+
+type ValidationError struct {
+	Errors []FieldError `json:"errors"`
+}
+
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+func (e *ValidationError) Error() string {
+	fields := make([]string, 0, len(e.Errors))
+	for _, fieldError := range e.Errors {
+		fields = append(fields, fieldError.Field+" "+fieldError.Message)
+	}
+	return "Validation error: " + strings.Join(fields, ", ")
+}
+
+func (e *ValidationError) Add(field string, message string) {
+	e.Errors = append(e.Errors, FieldError{field, message})
+}
+
+// Err returns nil if no field has been reported.
+func (e *ValidationError) Err() error {
+	if len(e.Errors) == 0 {
+		return nil
+	}
+	return e
+}
+
+// The rules every entity has to follow, checked in order on create, update
+// and bulk load.
+type userRule struct {
+	field   string
+	message string
+	valid   func(user *User) bool
+}
+
+type locationRule struct {
+	field   string
+	message string
+	valid   func(location *Location) bool
+}
+
+type visitRule struct {
+	field   string
+	message string
+	valid   func(visit *Visit) bool
+}
+
+var (
+	userRules = []userRule{
+		{"id", "is required", func(u *User) bool { return u.Id != 0 }},
+		{"email", "must be an email address of at most 100 characters", func(u *User) bool {
+			return isEmail(u.Email) && utf8.RuneCountInString(u.Email) <= 100
+		}},
+		{"first_name", "must be 1 to 50 characters long", func(u *User) bool { return lengthBetween(u.First_name, 1, 50) }},
+		{"last_name", "must be 1 to 50 characters long", func(u *User) bool { return lengthBetween(u.Last_name, 1, 50) }},
+		{"gender", "must be m or f", func(u *User) bool { return u.Gender == "m" || u.Gender == "f" }},
+		{"birth_date", "must be from 01.01.1930 to 01.01.1999", func(u *User) bool {
+			return u.Birth_date >= MinBirthDate && u.Birth_date <= MaxBirthDate
+		}},
+	}
+
+	locationRules = []locationRule{
+		{"id", "is required", func(l *Location) bool { return l.Id != 0 }},
+		{"place", "is required", func(l *Location) bool { return len(l.Place) > 0 }},
+		{"country", "must be 1 to 50 characters long", func(l *Location) bool { return lengthBetween(l.Country, 1, 50) }},
+		{"city", "must be 1 to 50 characters long", func(l *Location) bool { return lengthBetween(l.City, 1, 50) }},
+	}
+
+	visitRules = []visitRule{
+		{"id", "is required", func(v *Visit) bool { return v.Id != 0 }},
+		{"location", "is required", func(v *Visit) bool { return v.Location != 0 }},
+		{"user", "is required", func(v *Visit) bool { return v.User != 0 }},
+		{"visited_at", "must be from 01.01.2000 to 01.01.2015", func(v *Visit) bool {
+			return v.Visited_at >= MinVisitedAt && v.Visited_at <= MaxVisitedAt
+		}},
+		{"mark", "must be from 0 to 5", func(v *Visit) bool { return v.Mark <= 5 }},
+	}
+)
+
+func lengthBetween(value string, min int, max int) bool {
+	length := utf8.RuneCountInString(value)
+	return length >= min && length <= max
+}
+
+// isEmail checks the basic shape of an address: a local part and a domain
+// with a dot, without spaces.
+func isEmail(value string) bool {
+	at := strings.IndexByte(value, '@')
+	if at < 1 || strings.ContainsAny(value, " \t\r\n") || strings.Count(value, "@") != 1 {
+		return false
+	}
+	domain := value[at+1:]
+	dot := strings.LastIndexByte(domain, '.')
+	return dot > 0 && dot < len(domain)-1
+}
+
+// ValidateUser checks the user on its own; whether the email is taken is up
+// to the store.
+func ValidateUser(user *User) error {
+	var validation ValidationError
+	for _, rule := range userRules {
+		if !rule.valid(user) {
+			validation.Add(rule.field, rule.message)
+		}
+	}
+	return validation.Err()
+}
+
+func ValidateLocation(location *Location) error {
+	var validation ValidationError
+	for _, rule := range locationRules {
+		if !rule.valid(location) {
+			validation.Add(rule.field, rule.message)
+		}
+	}
+	return validation.Err()
+}
+
+func ValidateVisit(visit *Visit) error {
+	var validation ValidationError
+	for _, rule := range visitRules {
+		if !rule.valid(visit) {
+			validation.Add(rule.field, rule.message)
+		}
+	}
+	return validation.Err()
+}
@@ -0,0 +1,56 @@
+package model
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func validationFields(err error) []string {
+	validation, ok := err.(*ValidationError)
+	if !ok {
+		return nil
+	}
+	var fields []string
+	for _, fieldError := range validation.Errors {
+		fields = append(fields, fieldError.Field)
+	}
+	return fields
+}
+
+func TestValidateUser(t *testing.T) {
+	valid := User{Id: 900000200, Email: "valid@example.com", First_name: "Анна", Last_name: "Б", Gender: "f", Birth_date: 0}
+	if err := ValidateUser(&valid); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	tests := []struct {
+		change func(user *User)
+		fields []string
+	}{
+		{func(u *User) { u.Email = "no-at.example.com" }, []string{"email"}},
+		{func(u *User) { u.Email = "a@localhost" }, []string{"email"}},
+		{func(u *User) { u.Email = strings.Repeat("a", 100) + "@example.com" }, []string{"email"}},
+		{func(u *User) { u.First_name = strings.Repeat("я", 51) }, []string{"first_name"}},
+		{func(u *User) { u.Last_name = "" }, []string{"last_name"}},
+		{func(u *User) { u.Gender = "x"; u.Birth_date = MaxBirthDate + 1 }, []string{"gender", "birth_date"}},
+	}
+	for i, test := range tests {
+		user := valid
+		test.change(&user)
+		if fields := validationFields(ValidateUser(&user)); !reflect.DeepEqual(fields, test.fields) {
+			t.Errorf("test %d: got errors in %v, want %v", i, fields, test.fields)
+		}
+	}
+}
+
+func TestValidateVisit(t *testing.T) {
+	visit := Visit{Id: 1, Location: 1, User: 1, Visited_at: MinVisitedAt, Mark: 5}
+	if err := ValidateVisit(&visit); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	visit.Visited_at, visit.Mark = MaxVisitedAt+1, 6
+	if fields := validationFields(ValidateVisit(&visit)); !reflect.DeepEqual(fields, []string{"visited_at", "mark"}) {
+		t.Errorf("got errors in %v", fields)
+	}
+}
@@ -0,0 +1,17 @@
+package model
+
+// Visit is kept compact, there are many more visits than other entities:
+// ids are 32-bit like everywhere in the API and the fields are ordered so
+// the struct takes 32 bytes with no padding beyond the mark.
+//
+//easyjson:json
+type Visit struct {
+	Id         uint32 `json:"id"`
+	Location   uint32 `json:"location"`
+	User       uint32 `json:"user"`
+	Visited_at int32  `json:"visited_at"`
+	Mark       uint8  `json:"mark"`
+
+	Version   uint32 `json:"-"`
+	UpdatedAt int    `json:"-"`
+}
@@ -0,0 +1,128 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package model
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjsonE564fc13DecodeGithubComDiscHighloadcupModel(in *jlexer.Lexer, out *Visit) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "id":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Id = uint32(in.Uint32())
+			}
+		case "location":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Location = uint32(in.Uint32())
+			}
+		case "user":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.User = uint32(in.Uint32())
+			}
+		case "visited_at":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Visited_at = int32(in.Int32())
+			}
+		case "mark":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Mark = uint8(in.Uint8())
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonE564fc13EncodeGithubComDiscHighloadcupModel(out *jwriter.Writer, in Visit) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.Uint32(uint32(in.Id))
+	}
+	{
+		const prefix string = ",\"location\":"
+		out.RawString(prefix)
+		out.Uint32(uint32(in.Location))
+	}
+	{
+		const prefix string = ",\"user\":"
+		out.RawString(prefix)
+		out.Uint32(uint32(in.User))
+	}
+	{
+		const prefix string = ",\"visited_at\":"
+		out.RawString(prefix)
+		out.Int32(int32(in.Visited_at))
+	}
+	{
+		const prefix string = ",\"mark\":"
+		out.RawString(prefix)
+		out.Uint8(uint8(in.Mark))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Visit) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonE564fc13EncodeGithubComDiscHighloadcupModel(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Visit) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE564fc13EncodeGithubComDiscHighloadcupModel(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Visit) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonE564fc13DecodeGithubComDiscHighloadcupModel(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Visit) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE564fc13DecodeGithubComDiscHighloadcupModel(l, v)
+}
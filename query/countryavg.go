@@ -0,0 +1,49 @@
+package query
+
+import (
+	"sort"
+
+	"github.com/disc/highloadcup/store"
+)
+
+//easyjson:json
+type CountryAvg struct {
+	Country string          `json:"country"`
+	Avg     float64         `json:"avg"`
+	Visits  uint            `json:"visits"`
+	Cities  map[string]uint `json:"cities"`
+}
+
+type countryMarks struct {
+	sum    uint
+	count  uint
+	cities map[string]uint
+}
+
+// GetCountriesAvg merges the per-location marks of the given locations by
+// country, counting the matching visits of every city.
+func GetCountriesAvg(s *store.Store, ids []uint, filters LocationAvgFilter) []CountryAvg {
+	countries := make(map[string]*countryMarks)
+	for _, marks := range getLocationMarks(s, ids, filters) {
+		location := s.Locations.Get(marks.id)
+		country := countries[location.Country]
+		if country == nil {
+			country = &countryMarks{cities: make(map[string]uint)}
+			countries[location.Country] = country
+		}
+		country.sum += marks.sum
+		country.count += marks.count
+		country.cities[location.City] += marks.count
+	}
+
+	result := make([]CountryAvg, 0, len(countries))
+	for name, country := range countries {
+		var avg float64
+		if country.count > 0 {
+			avg = Round(float64(country.sum)/float64(country.count), .5, 5)
+		}
+		result = append(result, CountryAvg{name, avg, country.count, country.cities})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Country < result[j].Country })
+	return result
+}
@@ -0,0 +1,147 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package query
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson766ea78aDecodeGithubComDiscHighloadcupQuery(in *jlexer.Lexer, out *CountryAvg) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "country":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Country = string(in.String())
+			}
+		case "avg":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Avg = float64(in.Float64())
+			}
+		case "visits":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Visits = uint(in.Uint())
+			}
+		case "cities":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				out.Cities = make(map[string]uint)
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v1 uint
+					if in.IsNull() {
+						in.Skip()
+					} else {
+						v1 = uint(in.Uint())
+					}
+					(out.Cities)[key] = v1
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson766ea78aEncodeGithubComDiscHighloadcupQuery(out *jwriter.Writer, in CountryAvg) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"country\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Country))
+	}
+	{
+		const prefix string = ",\"avg\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.Avg))
+	}
+	{
+		const prefix string = ",\"visits\":"
+		out.RawString(prefix)
+		out.Uint(uint(in.Visits))
+	}
+	{
+		const prefix string = ",\"cities\":"
+		out.RawString(prefix)
+		if in.Cities == nil && (out.Flags&jwriter.NilMapAsEmpty) == 0 {
+			out.RawString(`null`)
+		} else {
+			out.RawByte('{')
+			v2First := true
+			for v2Name, v2Value := range in.Cities {
+				if v2First {
+					v2First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v2Name))
+				out.RawByte(':')
+				out.Uint(uint(v2Value))
+			}
+			out.RawByte('}')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v CountryAvg) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson766ea78aEncodeGithubComDiscHighloadcupQuery(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v CountryAvg) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson766ea78aEncodeGithubComDiscHighloadcupQuery(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *CountryAvg) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson766ea78aDecodeGithubComDiscHighloadcupQuery(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *CountryAvg) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson766ea78aDecodeGithubComDiscHighloadcupQuery(l, v)
+}
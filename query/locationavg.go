@@ -0,0 +1,65 @@
+package query
+
+import (
+	"github.com/disc/highloadcup/model"
+	"github.com/disc/highloadcup/store"
+)
+
+// LocationAvgFilter narrows the visits averaged for a location; ages are
+// the visitors' full years at the store's Now.
+type LocationAvgFilter struct {
+	FromDate *int
+	ToDate   *int
+	FromAge  *int
+	ToAge    *int
+	Gender   *[]byte
+}
+
+func (filters *LocationAvgFilter) matchesVisit(visit *model.Visit) bool {
+	if filters.FromDate != nil && int(visit.Visited_at) < *filters.FromDate {
+		return false
+	}
+	if filters.ToDate != nil && int(visit.Visited_at) > *filters.ToDate {
+		return false
+	}
+	return true
+}
+
+func (filters *LocationAvgFilter) matchesUser(user *model.User, now int) bool {
+	if filters.FromAge != nil && user.Birth_date > model.TimestampByAge(filters.FromAge, now) {
+		return false
+	}
+	if filters.ToAge != nil && user.Birth_date <= model.TimestampByAge(filters.ToAge, now) {
+		return false
+	}
+	if filters.Gender != nil && string(*filters.Gender) != user.Gender {
+		return false
+	}
+	return true
+}
+
+func GetLocationAvg(s *store.Store, locationId uint, filters LocationAvgFilter) float64 {
+	// Without filters the total kept by the store will do.
+	if filters == (LocationAvgFilter{}) {
+		if sum, count := s.Visits.LocationMarks(locationId); count > 0 {
+			return float64(sum) / float64(count)
+		}
+		return 0
+	}
+
+	marks := make([]uint, 0)
+	var marksSum uint
+	for _, visit := range s.Visits.ByLocation(locationId) {
+		if !filters.matchesVisit(visit) || !filters.matchesUser(s.Users.Get(uint(visit.User)), s.Now) {
+			continue
+		}
+		marksSum += uint(visit.Mark)
+		marks = append(marks, uint(visit.Mark))
+	}
+
+	if len(marks) > 0 {
+		return float64(marksSum) / float64(len(marks))
+	}
+
+	return 0
+}
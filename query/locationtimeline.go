@@ -0,0 +1,58 @@
+package query
+
+import (
+	"sort"
+	"time"
+
+	"github.com/disc/highloadcup/store"
+)
+
+//easyjson:json
+type TimelineBucket struct {
+	From  int     `json:"from"`
+	Count uint    `json:"count"`
+	Avg   float64 `json:"avg"`
+}
+
+// bucketStart truncates a unix timestamp to the start of its UTC day, ISO
+// week (starting on Monday), month or year.
+func bucketStart(timestamp int, bucket string) int {
+	t := time.Unix(int64(timestamp), 0).UTC()
+	switch bucket {
+	case "day":
+		t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	case "week":
+		t = time.Date(t.Year(), t.Month(), t.Day()-(int(t.Weekday())+6)%7, 0, 0, 0, 0, time.UTC)
+	case "month":
+		t = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	case "year":
+		t = time.Date(t.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+	}
+	return int(t.Unix())
+}
+
+func GetLocationTimeline(s *store.Store, locationId uint, bucket string, filters LocationAvgFilter) []TimelineBucket {
+	var (
+		sums    = make(map[int]uint)
+		buckets = make(map[int]*TimelineBucket)
+	)
+	for _, visit := range s.Visits.ByLocation(locationId) {
+		if !filters.matchesVisit(visit) || !filters.matchesUser(s.Users.Get(uint(visit.User)), s.Now) {
+			continue
+		}
+		from := bucketStart(int(visit.Visited_at), bucket)
+		if buckets[from] == nil {
+			buckets[from] = &TimelineBucket{From: from}
+		}
+		buckets[from].Count++
+		sums[from] += uint(visit.Mark)
+	}
+
+	timeline := make([]TimelineBucket, 0, len(buckets))
+	for from, b := range buckets {
+		b.Avg = Round(float64(sums[from])/float64(b.Count), .5, 5)
+		timeline = append(timeline, *b)
+	}
+	sort.Slice(timeline, func(i, j int) bool { return timeline[i].From < timeline[j].From })
+	return timeline
+}
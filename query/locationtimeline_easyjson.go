@@ -0,0 +1,106 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package query
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson6c2dd974DecodeGithubComDiscHighloadcupQuery(in *jlexer.Lexer, out *TimelineBucket) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "from":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.From = int(in.Int())
+			}
+		case "count":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Count = uint(in.Uint())
+			}
+		case "avg":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Avg = float64(in.Float64())
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6c2dd974EncodeGithubComDiscHighloadcupQuery(out *jwriter.Writer, in TimelineBucket) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"from\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.From))
+	}
+	{
+		const prefix string = ",\"count\":"
+		out.RawString(prefix)
+		out.Uint(uint(in.Count))
+	}
+	{
+		const prefix string = ",\"avg\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.Avg))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v TimelineBucket) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6c2dd974EncodeGithubComDiscHighloadcupQuery(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v TimelineBucket) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6c2dd974EncodeGithubComDiscHighloadcupQuery(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *TimelineBucket) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6c2dd974DecodeGithubComDiscHighloadcupQuery(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *TimelineBucket) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6c2dd974DecodeGithubComDiscHighloadcupQuery(l, v)
+}
@@ -0,0 +1,147 @@
+package query
+
+import (
+	"container/heap"
+	"runtime"
+	"sync"
+
+	"github.com/disc/highloadcup/store"
+)
+
+//easyjson:json
+type TopLocation struct {
+	Id       uint    `json:"id"`
+	Place    string  `json:"place"`
+	Country  string  `json:"country"`
+	City     string  `json:"city"`
+	Distance uint    `json:"distance"`
+	Avg      float64 `json:"avg"`
+	Score    float64 `json:"score"`
+	Visits   uint    `json:"visits"`
+}
+
+// LocationTopFilter ranks locations by a Bayesian average: every location's
+// marks are blended with prior virtual visits at the mean mark of all
+// candidates, so a handful of fives can't beat a well-visited place.
+// Locations with fewer than MinVisits matching visits are left out.
+type LocationTopFilter struct {
+	Visits    LocationAvgFilter
+	Country   *string
+	Limit     int
+	MinVisits uint
+	Prior     float64
+}
+
+type locationMarks struct {
+	id    uint
+	sum   uint
+	count uint
+	score float64
+}
+
+// locationMarksHeap is a min-heap on score, so its root is the weakest of
+// the locations kept so far.
+type locationMarksHeap []locationMarks
+
+func (h locationMarksHeap) Len() int { return len(h) }
+
+func (h locationMarksHeap) Less(i, j int) bool { return h[i].weaker(h[j]) }
+
+func (h locationMarksHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *locationMarksHeap) Push(x interface{}) { *h = append(*h, x.(locationMarks)) }
+
+func (h *locationMarksHeap) Pop() interface{} {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
+func (m locationMarks) weaker(other locationMarks) bool {
+	if m.score != other.score {
+		return m.score < other.score
+	}
+	if m.count != other.count {
+		return m.count < other.count
+	}
+	return m.id > other.id
+}
+
+func (filters *LocationAvgFilter) hasUserFilters() bool {
+	return filters.FromAge != nil || filters.ToAge != nil || filters.Gender != nil
+}
+
+// getLocationMarks sums the matching marks of every location in parallel.
+func getLocationMarks(s *store.Store, ids []uint, filters LocationAvgFilter) []locationMarks {
+	var (
+		marks   = make([]locationMarks, len(ids))
+		workers = runtime.NumCPU()
+		chunk   = (len(ids) + workers - 1) / workers
+		wg      sync.WaitGroup
+	)
+	for from := 0; from < len(ids); from += chunk {
+		to := from + chunk
+		if to > len(ids) {
+			to = len(ids)
+		}
+		wg.Add(1)
+		go func(from int, to int) {
+			defer wg.Done()
+			for i := from; i < to; i++ {
+				marks[i].id = ids[i]
+				for _, visit := range s.Visits.ByLocation(ids[i]) {
+					if !filters.matchesVisit(visit) {
+						continue
+					}
+					if filters.hasUserFilters() && !filters.matchesUser(s.Users.Get(uint(visit.User)), s.Now) {
+						continue
+					}
+					marks[i].sum += uint(visit.Mark)
+					marks[i].count++
+				}
+			}
+		}(from, to)
+	}
+	wg.Wait()
+	return marks
+}
+
+func GetTopLocations(s *store.Store, filter LocationTopFilter) []TopLocation {
+	marks := getLocationMarks(s, s.Locations.Ids(filter.Country), filter.Visits)
+
+	var sum, count uint
+	for _, m := range marks {
+		sum += m.sum
+		count += m.count
+	}
+	var mean float64
+	if count > 0 {
+		mean = float64(sum) / float64(count)
+	}
+
+	top := make(locationMarksHeap, 0, filter.Limit+1)
+	for _, m := range marks {
+		if m.count == 0 || m.count < filter.MinVisits {
+			continue
+		}
+		m.score = (float64(m.sum) + filter.Prior*mean) / (float64(m.count) + filter.Prior)
+		if len(top) < filter.Limit {
+			heap.Push(&top, m)
+		} else if top[0].weaker(m) {
+			top[0] = m
+			heap.Fix(&top, 0)
+		}
+	}
+
+	locations := make([]TopLocation, len(top))
+	for i := len(top) - 1; i >= 0; i-- {
+		m := heap.Pop(&top).(locationMarks)
+		location := s.Locations.Get(m.id)
+		locations[i] = TopLocation{
+			m.id, location.Place, location.Country, location.City, location.Distance,
+			Round(float64(m.sum)/float64(m.count), .5, 5), Round(m.score, .5, 5), m.count,
+		}
+	}
+	return locations
+}
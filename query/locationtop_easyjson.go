@@ -0,0 +1,161 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package query
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson230e1f9cDecodeGithubComDiscHighloadcupQuery(in *jlexer.Lexer, out *TopLocation) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "id":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Id = uint(in.Uint())
+			}
+		case "place":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Place = string(in.String())
+			}
+		case "country":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Country = string(in.String())
+			}
+		case "city":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.City = string(in.String())
+			}
+		case "distance":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Distance = uint(in.Uint())
+			}
+		case "avg":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Avg = float64(in.Float64())
+			}
+		case "score":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Score = float64(in.Float64())
+			}
+		case "visits":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Visits = uint(in.Uint())
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson230e1f9cEncodeGithubComDiscHighloadcupQuery(out *jwriter.Writer, in TopLocation) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.Uint(uint(in.Id))
+	}
+	{
+		const prefix string = ",\"place\":"
+		out.RawString(prefix)
+		out.String(string(in.Place))
+	}
+	{
+		const prefix string = ",\"country\":"
+		out.RawString(prefix)
+		out.String(string(in.Country))
+	}
+	{
+		const prefix string = ",\"city\":"
+		out.RawString(prefix)
+		out.String(string(in.City))
+	}
+	{
+		const prefix string = ",\"distance\":"
+		out.RawString(prefix)
+		out.Uint(uint(in.Distance))
+	}
+	{
+		const prefix string = ",\"avg\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.Avg))
+	}
+	{
+		const prefix string = ",\"score\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.Score))
+	}
+	{
+		const prefix string = ",\"visits\":"
+		out.RawString(prefix)
+		out.Uint(uint(in.Visits))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v TopLocation) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson230e1f9cEncodeGithubComDiscHighloadcupQuery(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v TopLocation) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson230e1f9cEncodeGithubComDiscHighloadcupQuery(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *TopLocation) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson230e1f9cDecodeGithubComDiscHighloadcupQuery(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *TopLocation) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson230e1f9cDecodeGithubComDiscHighloadcupQuery(l, v)
+}
@@ -0,0 +1,43 @@
+package query
+
+import (
+	"sort"
+
+	"github.com/disc/highloadcup/model"
+	"github.com/disc/highloadcup/store"
+)
+
+//easyjson:json
+type LocationVisit struct {
+	Id         uint   `json:"id"`
+	User       uint   `json:"user"`
+	Mark       uint   `json:"mark"`
+	Visited_at int    `json:"visited_at"`
+	Gender     string `json:"gender"`
+	Age        int    `json:"age"`
+}
+
+// GetLocationVisits lists the visits that feed GetLocationAvg for the same
+// filters, ordered by visit date.
+func GetLocationVisits(s *store.Store, locationId uint, filters LocationAvgFilter) []LocationVisit {
+	locationVisits := make([]LocationVisit, 0)
+	for _, visit := range s.Visits.ByLocation(locationId) {
+		if !filters.matchesVisit(visit) {
+			continue
+		}
+		// A visit can belong to a user that was never created.
+		user := s.Users.Get(uint(visit.User))
+		if user == nil || !filters.matchesUser(user, s.Now) {
+			continue
+		}
+		locationVisits = append(locationVisits, LocationVisit{
+			uint(visit.Id), uint(visit.User), uint(visit.Mark), int(visit.Visited_at), user.Gender, model.AgeByTimestamp(user.Birth_date, s.Now),
+		})
+	}
+
+	sort.Slice(locationVisits, func(i, j int) bool {
+		a, b := locationVisits[i], locationVisits[j]
+		return a.Visited_at < b.Visited_at || a.Visited_at == b.Visited_at && a.Id < b.Id
+	})
+	return locationVisits
+}
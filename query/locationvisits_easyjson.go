@@ -0,0 +1,139 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package query
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson8eab70c1DecodeGithubComDiscHighloadcupQuery(in *jlexer.Lexer, out *LocationVisit) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "id":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Id = uint(in.Uint())
+			}
+		case "user":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.User = uint(in.Uint())
+			}
+		case "mark":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Mark = uint(in.Uint())
+			}
+		case "visited_at":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Visited_at = int(in.Int())
+			}
+		case "gender":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Gender = string(in.String())
+			}
+		case "age":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Age = int(in.Int())
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson8eab70c1EncodeGithubComDiscHighloadcupQuery(out *jwriter.Writer, in LocationVisit) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.Uint(uint(in.Id))
+	}
+	{
+		const prefix string = ",\"user\":"
+		out.RawString(prefix)
+		out.Uint(uint(in.User))
+	}
+	{
+		const prefix string = ",\"mark\":"
+		out.RawString(prefix)
+		out.Uint(uint(in.Mark))
+	}
+	{
+		const prefix string = ",\"visited_at\":"
+		out.RawString(prefix)
+		out.Int(int(in.Visited_at))
+	}
+	{
+		const prefix string = ",\"gender\":"
+		out.RawString(prefix)
+		out.String(string(in.Gender))
+	}
+	{
+		const prefix string = ",\"age\":"
+		out.RawString(prefix)
+		out.Int(int(in.Age))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v LocationVisit) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson8eab70c1EncodeGithubComDiscHighloadcupQuery(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v LocationVisit) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson8eab70c1EncodeGithubComDiscHighloadcupQuery(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *LocationVisit) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson8eab70c1DecodeGithubComDiscHighloadcupQuery(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *LocationVisit) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson8eab70c1DecodeGithubComDiscHighloadcupQuery(l, v)
+}
@@ -0,0 +1,61 @@
+package query
+
+import (
+	"sync"
+	"testing"
+	//"math/rand"
+
+	"github.com/disc/highloadcup/loader"
+	"github.com/disc/highloadcup/store"
+)
+
+var (
+	loadDataOnce sync.Once
+	loadedStore  *store.Store
+)
+
+// loadData parses the unzipped data.zip from data/, as the server does on
+// start, into a store shared by the tests that only read it.
+func loadData() *store.Store {
+	loadDataOnce.Do(func() { loadedStore = loadFreshData() })
+	return loadedStore
+}
+
+// loadFreshData parses data/ into a store of the caller's own, for tests that
+// write to it.
+func loadFreshData() *store.Store {
+	s := store.NewStore(16)
+	if _, err := loader.ParseDataDir(s, "../data/"); err != nil {
+		panic(err)
+	}
+	return s
+}
+
+//func BenchmarkGetLocationAvg(b *testing.B)  {
+//	getLocationAvg(uint(rand.Intn(1000)), LocationAvgFilter{})
+//}
+//
+//func BenchmarkAddNewVisit(b *testing.B) {
+//	visit := Visit{9111,1,2, 7890, 5}
+//	visitsMap.Update(visit)
+//}
+//
+//func BenchmarkUpdateVisit(b *testing.B) {
+//	visit := visitsMap[uint(rand.Intn(1000))]
+//	updatedVisit := *visit
+//
+//	updatedVisit.Location = visit.Location + 1
+//	updatedVisit.User = visit.User + 1
+//
+//	visitsMap.Update(updatedVisit)
+//}
+
+func BenchmarkGetUserVisits(b *testing.B) {
+	s := loadData()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		//userId := uint(rand.Intn(1000))
+		GetUserVisits(s, 1, UserVisitsFilter{})
+	}
+
+}
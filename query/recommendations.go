@@ -0,0 +1,145 @@
+package query
+
+import (
+	"math"
+	"sort"
+
+	"github.com/disc/highloadcup/store"
+)
+
+//easyjson:json
+type Recommendation struct {
+	Id       uint    `json:"id"`
+	Place    string  `json:"place"`
+	Country  string  `json:"country"`
+	City     string  `json:"city"`
+	Distance uint    `json:"distance"`
+	Score    float64 `json:"score"`
+}
+
+type recommendationScore struct {
+	id     uint
+	marks  float64
+	weight float64
+	score  float64
+}
+
+// UserMarks averages the user's marks per visited location.
+func UserMarks(s *store.Store, userId uint) map[uint]float64 {
+	var (
+		sums   = make(map[uint]uint)
+		counts = make(map[uint]uint)
+	)
+	for _, visit := range s.Visits.ByUser(userId) {
+		sums[uint(visit.Location)] += uint(visit.Mark)
+		counts[uint(visit.Location)]++
+	}
+	marks := make(map[uint]float64, len(sums))
+	for location, sum := range sums {
+		marks[location] = float64(sum) / float64(counts[location])
+	}
+	return marks
+}
+
+// locationVisitors returns the distinct users who visited the location.
+func locationVisitors(s *store.Store, locationId uint) map[uint]struct{} {
+	visitors := make(map[uint]struct{})
+	for _, visit := range s.Visits.ByLocation(locationId) {
+		visitors[uint(visit.User)] = struct{}{}
+	}
+	return visitors
+}
+
+// GetRecommendations scores the locations the user has not visited by item-
+// item collaborative filtering. Two locations are as similar as the sets of
+// their visitors (the cosine of both, so 1 for the same visitors), and an
+// unvisited location scores the user's own marks of the visited ones
+// weighted by their similarity to it, shrunk by one unit of weight so that
+// a single weak similarity does not top the list.
+func GetRecommendations(s *store.Store, userId uint, country *string, limit int) []Recommendation {
+	var (
+		visited  = UserMarks(s, userId)
+		visitors = make(map[uint]int)
+		scores   = make(map[uint]*recommendationScore)
+	)
+	countVisitors := func(locationId uint) int {
+		count, ok := visitors[locationId]
+		if !ok {
+			count = len(locationVisitors(s, locationId))
+			visitors[locationId] = count
+		}
+		return count
+	}
+
+	// The visited locations are taken in order, so the scores sum up the same
+	// way on every call.
+	visitedIds := make([]uint, 0, len(visited))
+	for locationId := range visited {
+		visitedIds = append(visitedIds, locationId)
+	}
+	sort.Slice(visitedIds, func(i, j int) bool { return visitedIds[i] < visitedIds[j] })
+
+	for _, locationId := range visitedIds {
+		mark := visited[locationId]
+		// The users who visited both this location and each unvisited one.
+		common := make(map[uint]int)
+		for otherId := range locationVisitors(s, locationId) {
+			if otherId == userId {
+				continue
+			}
+			seen := make(map[uint]struct{})
+			for _, visit := range s.Visits.ByUser(otherId) {
+				candidate := uint(visit.Location)
+				if _, ok := visited[candidate]; ok {
+					continue
+				}
+				if _, ok := seen[candidate]; ok {
+					continue
+				}
+				seen[candidate] = struct{}{}
+				common[candidate]++
+			}
+		}
+
+		for candidate, shared := range common {
+			score := scores[candidate]
+			if score == nil {
+				// A visit can point to a location that was never created.
+				location := s.Locations.Get(candidate)
+				if location == nil || country != nil && location.Country != *country {
+					continue
+				}
+				score = &recommendationScore{id: candidate}
+				scores[candidate] = score
+			}
+			similarity := float64(shared) / math.Sqrt(float64(countVisitors(locationId)*countVisitors(candidate)))
+			score.marks += similarity * mark
+			score.weight += similarity
+		}
+	}
+
+	ranked := make([]*recommendationScore, 0, len(scores))
+	for _, score := range scores {
+		score.score = Round(score.marks/(score.weight+1), .5, 5)
+		ranked = append(ranked, score)
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		a, b := ranked[i], ranked[j]
+		if a.score != b.score {
+			return a.score > b.score
+		}
+		return a.id < b.id
+	})
+
+	if len(ranked) > limit {
+		ranked = ranked[:limit]
+	}
+	recommendations := make([]Recommendation, 0, len(ranked))
+	for _, score := range ranked {
+		location := s.Locations.Get(score.id)
+		recommendations = append(recommendations, Recommendation{
+			location.Id, location.Place, location.Country, location.City, location.Distance, score.score,
+		})
+	}
+	return recommendations
+}
@@ -0,0 +1,62 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/disc/highloadcup/model"
+	"github.com/disc/highloadcup/store"
+)
+
+func TestRecommendations(t *testing.T) {
+	t.Parallel()
+	s := loadData()
+
+	recommendations := GetRecommendations(s, 1, nil, 3)
+	expected := []uint{61, 105, 79}
+	if len(recommendations) != len(expected) {
+		t.Fatalf("got %d recommendations, want %d", len(recommendations), len(expected))
+	}
+	for i, recommendation := range recommendations {
+		if recommendation.Id != expected[i] {
+			t.Errorf("recommendation %d: got location %d, want %d", i, recommendation.Id, expected[i])
+		}
+	}
+
+	visited := UserMarks(s, 1)
+	all := GetRecommendations(s, 1, nil, 1000)
+	for i, recommendation := range all {
+		if _, ok := visited[recommendation.Id]; ok {
+			t.Errorf("location %d is already visited by the user", recommendation.Id)
+		}
+		if i > 0 && recommendation.Score > all[i-1].Score {
+			t.Errorf("recommendation %d scores higher than the previous one", i)
+		}
+	}
+
+	country := "Египет"
+	for _, recommendation := range GetRecommendations(s, 1, &country, 1000) {
+		if recommendation.Country != country {
+			t.Errorf("location %d is in %s, want %s", recommendation.Id, recommendation.Country, country)
+		}
+	}
+}
+
+// A co-visitor's visit to a location that does not exist is left out.
+func TestRecommendationsMissingLocation(t *testing.T) {
+	t.Parallel()
+	s := store.NewStore(0)
+	s.Locations.Update(model.Location{Id: 1, Country: "Египет"})
+	s.Locations.Update(model.Location{Id: 2, Country: "Египет"})
+	s.Visits.Update(model.Visit{Id: 1, Location: 1, User: 1, Mark: 4})
+	s.Visits.Update(model.Visit{Id: 2, Location: 1, User: 2, Mark: 5})
+	s.Visits.Update(model.Visit{Id: 3, Location: 2, User: 2, Mark: 5})
+	s.Visits.Update(model.Visit{Id: 4, Location: 99, User: 2, Mark: 5})
+
+	egypt := "Египет"
+	for _, country := range []*string{nil, &egypt} {
+		recommendations := GetRecommendations(s, 1, country, 10)
+		if len(recommendations) != 1 || recommendations[0].Id != 2 {
+			t.Errorf("got %v", recommendations)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package query
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/disc/highloadcup/model"
+	"github.com/disc/highloadcup/store"
+)
+
+const similarUsersCacheSize = 10000
+
+//easyjson:json
+type SimilarUser struct {
+	Id         uint    `json:"id"`
+	First_name string  `json:"first_name"`
+	Last_name  string  `json:"last_name"`
+	Gender     string  `json:"gender"`
+	Birth_date int     `json:"birth_date"`
+	Common     int     `json:"common"`
+	Score      float64 `json:"score"`
+}
+
+// SimilarUsersFilter narrows the ranked similar users; at most Limit are
+// returned.
+type SimilarUsersFilter struct {
+	Gender  *string
+	FromAge *int
+	ToAge   *int
+	Limit   int
+}
+
+type userSimilarity struct {
+	id     uint
+	common int
+	score  float64
+}
+
+// SimilarUsersCache keeps the ranked similar users of every user asked for,
+// valid for as long as no visit has been written since.
+type SimilarUsersCache struct {
+	store      *store.Store
+	generation uint64
+	users      map[uint][]userSimilarity
+	sync.Mutex
+}
+
+func NewSimilarUsersCache(s *store.Store) *SimilarUsersCache {
+	return &SimilarUsersCache{store: s, users: make(map[uint][]userSimilarity)}
+}
+
+func (c *SimilarUsersCache) get(userId uint) []userSimilarity {
+	generation := c.store.Visits.Generation()
+
+	c.Lock()
+	if c.generation != generation {
+		c.generation = generation
+		c.users = make(map[uint][]userSimilarity)
+	}
+	similar, ok := c.users[userId]
+	c.Unlock()
+	if ok {
+		return similar
+	}
+
+	similar = getUserSimilarities(c.store, userId)
+
+	c.Lock()
+	if c.generation == generation {
+		if len(c.users) >= similarUsersCacheSize {
+			c.users = make(map[uint][]userSimilarity)
+		}
+		c.users[userId] = similar
+	}
+	c.Unlock()
+	return similar
+}
+
+// getUserSimilarities ranks the users who visited at least one of the
+// user's locations. The score is the Jaccard index of both users' visited
+// locations times how well their marks of the shared locations agree on
+// average (1 for the same marks, 0 for marks 5 apart).
+func getUserSimilarities(s *store.Store, userId uint) []userSimilarity {
+	marks := UserMarks(s, userId)
+
+	var (
+		agreements = make(map[uint]float64)
+		common     = make(map[uint]int)
+	)
+	for location, mark := range marks {
+		// Other users' marks of the location, averaged over their repeated visits.
+		var (
+			sums   = make(map[uint]uint)
+			counts = make(map[uint]uint)
+		)
+		for _, visit := range s.Visits.ByLocation(location) {
+			if uint(visit.User) == userId {
+				continue
+			}
+			sums[uint(visit.User)] += uint(visit.Mark)
+			counts[uint(visit.User)]++
+		}
+		for otherId, sum := range sums {
+			diff := mark - float64(sum)/float64(counts[otherId])
+			if diff < 0 {
+				diff = -diff
+			}
+			agreements[otherId] += 1 - diff/5
+			common[otherId]++
+		}
+	}
+
+	similar := make([]userSimilarity, 0, len(common))
+	for otherId, shared := range common {
+		locations := make(map[uint]struct{})
+		for _, visit := range s.Visits.ByUser(otherId) {
+			locations[uint(visit.Location)] = struct{}{}
+		}
+		jaccard := float64(shared) / float64(len(marks)+len(locations)-shared)
+		agreement := agreements[otherId] / float64(shared)
+		similar = append(similar, userSimilarity{otherId, shared, Round(jaccard*agreement, .5, 5)})
+	}
+	sort.Slice(similar, func(i, j int) bool {
+		a, b := similar[i], similar[j]
+		if a.score != b.score {
+			return a.score > b.score
+		}
+		return a.id < b.id
+	})
+	return similar
+}
+
+func (filter *SimilarUsersFilter) matchesUser(user *model.User, now int) bool {
+	if filter.Gender != nil && user.Gender != *filter.Gender {
+		return false
+	}
+	if filter.FromAge != nil && user.Birth_date > model.TimestampByAge(filter.FromAge, now) {
+		return false
+	}
+	if filter.ToAge != nil && user.Birth_date <= model.TimestampByAge(filter.ToAge, now) {
+		return false
+	}
+	return true
+}
+
+// GetSimilarUsers returns the most similar users matching the filter.
+func (c *SimilarUsersCache) GetSimilarUsers(userId uint, filter SimilarUsersFilter) []SimilarUser {
+	users := make([]SimilarUser, 0, filter.Limit)
+	for _, similarity := range c.get(userId) {
+		if len(users) == filter.Limit {
+			break
+		}
+		user := c.store.Users.Get(similarity.id)
+		if user == nil || !filter.matchesUser(user, c.store.Now) {
+			continue
+		}
+		users = append(users, SimilarUser{
+			user.Id, user.First_name, user.Last_name, user.Gender, user.Birth_date, similarity.common, similarity.score,
+		})
+	}
+	return users
+}
@@ -0,0 +1,150 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package query
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjsonA492bd8dDecodeGithubComDiscHighloadcupQuery(in *jlexer.Lexer, out *SimilarUser) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "id":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Id = uint(in.Uint())
+			}
+		case "first_name":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.First_name = string(in.String())
+			}
+		case "last_name":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Last_name = string(in.String())
+			}
+		case "gender":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Gender = string(in.String())
+			}
+		case "birth_date":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Birth_date = int(in.Int())
+			}
+		case "common":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Common = int(in.Int())
+			}
+		case "score":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Score = float64(in.Float64())
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonA492bd8dEncodeGithubComDiscHighloadcupQuery(out *jwriter.Writer, in SimilarUser) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.Uint(uint(in.Id))
+	}
+	{
+		const prefix string = ",\"first_name\":"
+		out.RawString(prefix)
+		out.String(string(in.First_name))
+	}
+	{
+		const prefix string = ",\"last_name\":"
+		out.RawString(prefix)
+		out.String(string(in.Last_name))
+	}
+	{
+		const prefix string = ",\"gender\":"
+		out.RawString(prefix)
+		out.String(string(in.Gender))
+	}
+	{
+		const prefix string = ",\"birth_date\":"
+		out.RawString(prefix)
+		out.Int(int(in.Birth_date))
+	}
+	{
+		const prefix string = ",\"common\":"
+		out.RawString(prefix)
+		out.Int(int(in.Common))
+	}
+	{
+		const prefix string = ",\"score\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.Score))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v SimilarUser) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonA492bd8dEncodeGithubComDiscHighloadcupQuery(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v SimilarUser) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonA492bd8dEncodeGithubComDiscHighloadcupQuery(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *SimilarUser) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonA492bd8dDecodeGithubComDiscHighloadcupQuery(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *SimilarUser) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonA492bd8dDecodeGithubComDiscHighloadcupQuery(l, v)
+}
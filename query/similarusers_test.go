@@ -0,0 +1,24 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/disc/highloadcup/model"
+)
+
+func TestSimilarUsersCache(t *testing.T) {
+	t.Parallel()
+	cache := NewSimilarUsersCache(loadFreshData())
+
+	first := cache.GetSimilarUsers(1, SimilarUsersFilter{Limit: 3})
+	if len(first) != 3 || first[0].Id != 349 {
+		t.Fatalf("unexpected similar users %+v", first)
+	}
+
+	// A visit shared only by users 1 and 349 has to show up in the next answer.
+	cache.store.Visits.Update(model.Visit{Id: 900000001, Location: 1, User: 1, Visited_at: 1000000000, Mark: 5})
+	cache.store.Visits.Update(model.Visit{Id: 900000002, Location: 1, User: 349, Visited_at: 1000000000, Mark: 5})
+	if second := cache.GetSimilarUsers(1, SimilarUsersFilter{Limit: 1}); second[0].Common != first[0].Common+1 {
+		t.Errorf("got %d common locations after the new visits, want %d", second[0].Common, first[0].Common+1)
+	}
+}
@@ -0,0 +1,54 @@
+package query
+
+import (
+	"github.com/disc/highloadcup/store"
+)
+
+//easyjson:json
+type UserStats struct {
+	Visits     int            `json:"visits"`
+	Locations  int            `json:"locations"`
+	Countries  int            `json:"countries"`
+	AvgMark    float64        `json:"avg_mark"`
+	FirstVisit *int           `json:"first_visit"`
+	LastVisit  *int           `json:"last_visit"`
+	ByCountry  map[string]int `json:"by_country"`
+}
+
+func GetUserStats(s *store.Store, userId uint, filters UserVisitsFilter) UserStats {
+	var (
+		stats     = UserStats{ByCountry: make(map[string]int)}
+		locations = make(map[uint]struct{})
+		marksSum  uint
+	)
+	for _, visit := range s.Visits.ByUser(userId) {
+		if !filters.matchesVisit(visit) {
+			continue
+		}
+		// A visit can point to a location that was never created.
+		location := s.Locations.Get(uint(visit.Location))
+		if location == nil || !filters.matchesLocation(location) {
+			continue
+		}
+
+		stats.Visits++
+		marksSum += uint(visit.Mark)
+		locations[location.Id] = struct{}{}
+		stats.ByCountry[location.Country]++
+
+		visitedAt := int(visit.Visited_at)
+		if stats.FirstVisit == nil || visitedAt < *stats.FirstVisit {
+			stats.FirstVisit = &visitedAt
+		}
+		if stats.LastVisit == nil || visitedAt > *stats.LastVisit {
+			stats.LastVisit = &visitedAt
+		}
+	}
+
+	stats.Locations = len(locations)
+	stats.Countries = len(stats.ByCountry)
+	if stats.Visits > 0 {
+		stats.AvgMark = Round(float64(marksSum)/float64(stats.Visits), .5, 5)
+	}
+	return stats
+}
@@ -0,0 +1,204 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package query
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson60bf9f50DecodeGithubComDiscHighloadcupQuery(in *jlexer.Lexer, out *UserStats) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "visits":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Visits = int(in.Int())
+			}
+		case "locations":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Locations = int(in.Int())
+			}
+		case "countries":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Countries = int(in.Int())
+			}
+		case "avg_mark":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.AvgMark = float64(in.Float64())
+			}
+		case "first_visit":
+			if in.IsNull() {
+				in.Skip()
+				out.FirstVisit = nil
+			} else {
+				if out.FirstVisit == nil {
+					out.FirstVisit = new(int)
+				}
+				if in.IsNull() {
+					in.Skip()
+				} else {
+					*out.FirstVisit = int(in.Int())
+				}
+			}
+		case "last_visit":
+			if in.IsNull() {
+				in.Skip()
+				out.LastVisit = nil
+			} else {
+				if out.LastVisit == nil {
+					out.LastVisit = new(int)
+				}
+				if in.IsNull() {
+					in.Skip()
+				} else {
+					*out.LastVisit = int(in.Int())
+				}
+			}
+		case "by_country":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				out.ByCountry = make(map[string]int)
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v1 int
+					if in.IsNull() {
+						in.Skip()
+					} else {
+						v1 = int(in.Int())
+					}
+					(out.ByCountry)[key] = v1
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson60bf9f50EncodeGithubComDiscHighloadcupQuery(out *jwriter.Writer, in UserStats) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"visits\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.Visits))
+	}
+	{
+		const prefix string = ",\"locations\":"
+		out.RawString(prefix)
+		out.Int(int(in.Locations))
+	}
+	{
+		const prefix string = ",\"countries\":"
+		out.RawString(prefix)
+		out.Int(int(in.Countries))
+	}
+	{
+		const prefix string = ",\"avg_mark\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.AvgMark))
+	}
+	{
+		const prefix string = ",\"first_visit\":"
+		out.RawString(prefix)
+		if in.FirstVisit == nil {
+			out.RawString("null")
+		} else {
+			out.Int(int(*in.FirstVisit))
+		}
+	}
+	{
+		const prefix string = ",\"last_visit\":"
+		out.RawString(prefix)
+		if in.LastVisit == nil {
+			out.RawString("null")
+		} else {
+			out.Int(int(*in.LastVisit))
+		}
+	}
+	{
+		const prefix string = ",\"by_country\":"
+		out.RawString(prefix)
+		if in.ByCountry == nil && (out.Flags&jwriter.NilMapAsEmpty) == 0 {
+			out.RawString(`null`)
+		} else {
+			out.RawByte('{')
+			v2First := true
+			for v2Name, v2Value := range in.ByCountry {
+				if v2First {
+					v2First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v2Name))
+				out.RawByte(':')
+				out.Int(int(v2Value))
+			}
+			out.RawByte('}')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v UserStats) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson60bf9f50EncodeGithubComDiscHighloadcupQuery(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v UserStats) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson60bf9f50EncodeGithubComDiscHighloadcupQuery(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *UserStats) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson60bf9f50DecodeGithubComDiscHighloadcupQuery(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *UserStats) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson60bf9f50DecodeGithubComDiscHighloadcupQuery(l, v)
+}
@@ -0,0 +1,81 @@
+package query
+
+import (
+	"sort"
+
+	"github.com/disc/highloadcup/model"
+	"github.com/disc/highloadcup/store"
+)
+
+//easyjson:json
+type UserVisit struct {
+	Mark       uint   `json:"mark"`
+	Visited_at int    `json:"visited_at"`
+	Place      string `json:"place"`
+}
+
+// UserVisitsFilter narrows a user's visits; AsOf reads them from the
+// revision history as they were at that time.
+type UserVisitsFilter struct {
+	FromDate   *int
+	ToDate     *int
+	Country    *string
+	ToDistance *uint
+	AsOf       *int
+}
+
+func (filters *UserVisitsFilter) matchesVisit(visit *model.Visit) bool {
+	if filters.FromDate != nil && int(visit.Visited_at) < *filters.FromDate {
+		return false
+	}
+	if filters.ToDate != nil && int(visit.Visited_at) > *filters.ToDate {
+		return false
+	}
+	return true
+}
+
+func (filters *UserVisitsFilter) matchesLocation(location *model.Location) bool {
+	if filters.Country != nil && location.Country != *filters.Country {
+		return false
+	}
+	if filters.ToDistance != nil && location.Distance >= *filters.ToDistance {
+		return false
+	}
+	return true
+}
+
+func GetUserVisits(s *store.Store, userId uint, filters UserVisitsFilter) []UserVisit {
+	var userVisits = make(map[int]UserVisit, 0)
+	visits := s.Visits.ByUser(userId)
+	if filters.AsOf != nil {
+		visits = s.Visits.UserVisitsAsOf(userId, *filters.AsOf)
+	}
+	for _, visit := range visits {
+		if !filters.matchesVisit(visit) {
+			continue
+		}
+		location := s.Locations.Get(uint(visit.Location))
+		if filters.AsOf != nil {
+			if location = s.Locations.GetAsOf(uint(visit.Location), *filters.AsOf); location == nil {
+				continue
+			}
+		}
+		if !filters.matchesLocation(location) {
+			continue
+		}
+		userVisits[int(visit.Visited_at)] = UserVisit{uint(visit.Mark), int(visit.Visited_at), location.Place}
+	}
+
+	visitedAtList := make([]int, 0, len(userVisits))
+	for visitedAt := range userVisits {
+		visitedAtList = append(visitedAtList, visitedAt)
+	}
+	sort.Ints(visitedAtList)
+
+	sortedUserVisits := make([]UserVisit, 0, len(userVisits))
+	for _, visitedAt := range visitedAtList {
+		sortedUserVisits = append(sortedUserVisits, userVisits[visitedAt])
+	}
+
+	return sortedUserVisits
+}
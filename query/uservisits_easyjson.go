@@ -0,0 +1,106 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package query
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjsonF3338c15DecodeGithubComDiscHighloadcupQuery(in *jlexer.Lexer, out *UserVisit) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "mark":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Mark = uint(in.Uint())
+			}
+		case "visited_at":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Visited_at = int(in.Int())
+			}
+		case "place":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Place = string(in.String())
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonF3338c15EncodeGithubComDiscHighloadcupQuery(out *jwriter.Writer, in UserVisit) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"mark\":"
+		out.RawString(prefix[1:])
+		out.Uint(uint(in.Mark))
+	}
+	{
+		const prefix string = ",\"visited_at\":"
+		out.RawString(prefix)
+		out.Int(int(in.Visited_at))
+	}
+	{
+		const prefix string = ",\"place\":"
+		out.RawString(prefix)
+		out.String(string(in.Place))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v UserVisit) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonF3338c15EncodeGithubComDiscHighloadcupQuery(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v UserVisit) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonF3338c15EncodeGithubComDiscHighloadcupQuery(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *UserVisit) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonF3338c15DecodeGithubComDiscHighloadcupQuery(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *UserVisit) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonF3338c15DecodeGithubComDiscHighloadcupQuery(l, v)
+}
@@ -1,4 +1,5 @@
-package main
+// Package query computes the aggregates the API serves from a store.
+package query
 
 import (
 	"math"
@@ -19,7 +20,3 @@ func Round(val float64, roundOn float64, places int) (newVal float64) {
 	newVal = round / pow
 	return
 }
-
-func getTimestampByAge(age *int, now int) int {
-	return now - (*age)*int(math.Floor(365.25*24*60*60))
-}
@@ -0,0 +1,347 @@
+package store
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/disc/highloadcup/model"
+	"github.com/mailru/easyjson"
+)
+
+// fileRecord is one line of an entity file. Versions are not recorded, they
+// come out the same when the writes are replayed in order.
+//
+//easyjson:json
+type fileRecord struct {
+	UpdatedAt int             `json:"updated_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// The files of a file store, and the marker created once the data set has
+// been loaded into them in full.
+const (
+	usersFile     = "users.jsonl"
+	locationsFile = "locations.jsonl"
+	visitsFile    = "visits.jsonl"
+	loadedMarker  = "loaded"
+)
+
+// entityFile appends every write of one entity type to a file, one JSON
+// record per line. size is the length of the records written in full.
+type entityFile struct {
+	file *os.File
+	size int64
+}
+
+// openEntityFile replays the records of the file and opens it for appending.
+// A last record without its newline was cut off by a crash and is dropped.
+func openEntityFile(filename string, replay func(record *fileRecord) error) (*entityFile, error) {
+	file, err := os.OpenFile(filename, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		reader = bufio.NewReader(file)
+		offset int64
+	)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			file.Close()
+			return nil, err
+		}
+
+		var record fileRecord
+		if err := easyjson.Unmarshal(line, &record); err != nil {
+			file.Close()
+			return nil, fmt.Errorf("%s at offset %d: %s", filename, offset, err)
+		}
+		if err := replay(&record); err != nil {
+			file.Close()
+			return nil, fmt.Errorf("%s at offset %d: %s", filename, offset, err)
+		}
+		offset += int64(len(line))
+	}
+
+	if err := file.Truncate(offset); err != nil {
+		file.Close()
+		return nil, err
+	}
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return &entityFile{file, offset}, nil
+}
+
+// append writes the record of a write. On an error a partly written record
+// is cut off again, and the caller has to refuse the write.
+func (f *entityFile) append(updatedAt int, entity easyjson.Marshaler) error {
+	data, err := easyjson.Marshal(entity)
+	if err != nil {
+		return err
+	}
+	line, err := easyjson.Marshal(fileRecord{updatedAt, data})
+	if err != nil {
+		return err
+	}
+	if _, err := f.file.Write(append(line, '\n')); err != nil {
+		f.file.Truncate(f.size)
+		f.file.Seek(f.size, io.SeekStart)
+		return fmt.Errorf("Can't write %s: %s", f.file.Name(), err)
+	}
+	f.size += int64(len(line)) + 1
+	return nil
+}
+
+func (f *entityFile) Sync() error {
+	return f.file.Sync()
+}
+
+// Close flushes the file to disk and closes it.
+func (f *entityFile) Close() error {
+	if err := f.file.Sync(); err != nil {
+		f.file.Close()
+		return err
+	}
+	return f.file.Close()
+}
+
+// ErrWriteFailed stands for a write a file store could not append, and so did
+// not apply, where Update reports it as version 0.
+var ErrWriteFailed = errors.New("The entity could not be written")
+
+// fileUsers keeps the users in memory like UsersMap and appends every write
+// to a file under the same lock, so the file replays in the order the
+// writes were applied. A write that can't be appended is not applied
+// either: Update logs the error and returns version 0 for it, while
+// UpdateIfVersion and UpdateUnique return the error.
+type fileUsers struct {
+	*UsersMap
+	*entityFile
+}
+
+func (u *fileUsers) Update(user model.User) (uint, *model.User) {
+	u.Lock()
+	defer u.Unlock()
+
+	if err := u.append(user.UpdatedAt, &user); err != nil {
+		log.Print(err)
+		return 0, nil
+	}
+	return u.update(user)
+}
+
+func (u *fileUsers) UpdateIfVersion(user model.User, version uint) (*model.User, error) {
+	u.Lock()
+	defer u.Unlock()
+
+	if prev := u.Get(user.Id); prev == nil || uint(prev.Version) != version {
+		return nil, ErrVersionMismatch
+	}
+	if err := u.append(user.UpdatedAt, &user); err != nil {
+		return nil, err
+	}
+	_, prev := u.update(user)
+	return prev, nil
+}
+
+func (u *fileUsers) UpdateUnique(user model.User, version *uint) (*model.User, error) {
+	u.Lock()
+	defer u.Unlock()
+
+	if err := u.checkUnique(&user, version); err != nil {
+		return nil, err
+	}
+	if err := u.append(user.UpdatedAt, &user); err != nil {
+		return nil, err
+	}
+	_, prev := u.update(user)
+	return prev, nil
+}
+
+// Close closes the file once the write in progress, if any, is done.
+func (u *fileUsers) Close() error {
+	u.Lock()
+	defer u.Unlock()
+
+	return u.entityFile.Close()
+}
+
+type fileLocations struct {
+	*LocationsMap
+	*entityFile
+}
+
+func (l *fileLocations) Update(location model.Location) (uint, *model.Location) {
+	l.Lock()
+	defer l.Unlock()
+
+	if err := l.append(location.UpdatedAt, &location); err != nil {
+		log.Print(err)
+		return 0, nil
+	}
+	return l.update(location)
+}
+
+func (l *fileLocations) UpdateIfVersion(location model.Location, version uint) (*model.Location, error) {
+	l.Lock()
+	defer l.Unlock()
+
+	if prev := l.Get(location.Id); prev == nil || uint(prev.Version) != version {
+		return nil, ErrVersionMismatch
+	}
+	if err := l.append(location.UpdatedAt, &location); err != nil {
+		return nil, err
+	}
+	_, prev := l.update(location)
+	return prev, nil
+}
+
+func (l *fileLocations) Close() error {
+	l.Lock()
+	defer l.Unlock()
+
+	return l.entityFile.Close()
+}
+
+type fileVisits struct {
+	*VisitsMap
+	*entityFile
+}
+
+func (v *fileVisits) Update(visit model.Visit) (uint, *model.Visit) {
+	v.Lock()
+	defer v.Unlock()
+
+	if err := v.append(visit.UpdatedAt, &visit); err != nil {
+		log.Print(err)
+		return 0, nil
+	}
+	return v.update(visit)
+}
+
+func (v *fileVisits) UpdateIfVersion(visit model.Visit, version uint) (*model.Visit, error) {
+	v.Lock()
+	defer v.Unlock()
+
+	if prev := v.Get(uint(visit.Id)); prev == nil || uint(prev.Version) != version {
+		return nil, ErrVersionMismatch
+	}
+	if err := v.append(visit.UpdatedAt, &visit); err != nil {
+		return nil, err
+	}
+	_, prev := v.update(visit)
+	return prev, nil
+}
+
+func (v *fileVisits) Close() error {
+	v.Lock()
+	defer v.Unlock()
+
+	return v.entityFile.Close()
+}
+
+// OpenFileStore opens the store kept in users.jsonl, locations.jsonl and
+// visits.jsonl in dir, creating them if needed. The entities are read back
+// into the given empty store from NewStore or NewDenseStore, so reads are as
+// fast as from it and only writes touch the files.
+func OpenFileStore(dir string, s *Store) (*Store, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	users := &fileUsers{UsersMap: s.Users.(*UsersMap)}
+	locations := &fileLocations{LocationsMap: s.Locations.(*LocationsMap)}
+	visits := &fileVisits{VisitsMap: s.Visits.(*VisitsMap)}
+
+	var err error
+	users.entityFile, err = openEntityFile(filepath.Join(dir, usersFile), func(record *fileRecord) error {
+		var user model.User
+		if err := easyjson.Unmarshal(record.Data, &user); err != nil {
+			return err
+		}
+		user.UpdatedAt = record.UpdatedAt
+		users.update(user)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	locations.entityFile, err = openEntityFile(filepath.Join(dir, locationsFile), func(record *fileRecord) error {
+		var location model.Location
+		if err := easyjson.Unmarshal(record.Data, &location); err != nil {
+			return err
+		}
+		location.UpdatedAt = record.UpdatedAt
+		locations.update(location)
+		return nil
+	})
+	if err != nil {
+		users.Close()
+		return nil, err
+	}
+	visits.entityFile, err = openEntityFile(filepath.Join(dir, visitsFile), func(record *fileRecord) error {
+		var visit model.Visit
+		if err := easyjson.Unmarshal(record.Data, &visit); err != nil {
+			return err
+		}
+		visit.UpdatedAt = record.UpdatedAt
+		visits.update(visit)
+		return nil
+	})
+	if err != nil {
+		users.Close()
+		locations.Close()
+		return nil, err
+	}
+
+	s.Users, s.Locations, s.Visits = users, locations, visits
+	return s, nil
+}
+
+// FileStoreLoaded tells whether MarkFileStoreLoaded has recorded the data set
+// as loaded into the file store in dir.
+func FileStoreLoaded(dir string) bool {
+	_, err := os.Stat(filepath.Join(dir, loadedMarker))
+	return err == nil
+}
+
+// MarkFileStoreLoaded flushes the files of the store opened from dir to disk
+// and records that they hold the data set in full, so a restart continues
+// from them instead of loading it again.
+func MarkFileStoreLoaded(dir string, s *Store) error {
+	if err := s.Sync(); err != nil {
+		return err
+	}
+	marker, err := os.Create(filepath.Join(dir, loadedMarker))
+	if err != nil {
+		return err
+	}
+	if err := marker.Sync(); err != nil {
+		marker.Close()
+		return err
+	}
+	return marker.Close()
+}
+
+// RemoveFileStore removes the files of the store in dir, for a data set
+// that has to be loaded again.
+func RemoveFileStore(dir string) error {
+	for _, name := range []string{loadedMarker, usersFile, locationsFile, visitsFile} {
+		if err := os.Remove(filepath.Join(dir, name)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
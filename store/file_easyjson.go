@@ -0,0 +1,97 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package store
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson8ceb9162DecodeGithubComDiscHighloadcupStore(in *jlexer.Lexer, out *fileRecord) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "updated_at":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.UpdatedAt = int(in.Int())
+			}
+		case "data":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				if data := in.Raw(); in.Ok() {
+					in.AddError((out.Data).UnmarshalJSON(data))
+				}
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson8ceb9162EncodeGithubComDiscHighloadcupStore(out *jwriter.Writer, in fileRecord) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"updated_at\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.UpdatedAt))
+	}
+	{
+		const prefix string = ",\"data\":"
+		out.RawString(prefix)
+		out.Raw((in.Data).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v fileRecord) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson8ceb9162EncodeGithubComDiscHighloadcupStore(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v fileRecord) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson8ceb9162EncodeGithubComDiscHighloadcupStore(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *fileRecord) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson8ceb9162DecodeGithubComDiscHighloadcupStore(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *fileRecord) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson8ceb9162DecodeGithubComDiscHighloadcupStore(l, v)
+}
@@ -0,0 +1,151 @@
+package store
+
+import (
+	"sync"
+	"sync/atomic"
+	"unsafe"
+)
+
+// denseMinLen is the number of slots a dense table starts growing with, so
+// the first ids don't count as sparse while the table is still small.
+const denseMinLen = 1024
+
+// idTable holds the entities of one type by id. Without dense slots every id
+// is kept in a map behind a lock. With them, an id that fits into the slots,
+// or into twice as many as there are entities, gets a slot of a slice indexed
+// by id. The slice is copied when it grows and swapped in atomically and the
+// slots are loaded atomically, so reading them takes no lock. Ids far beyond
+// the others fall back to the map until the slots grow past them.
+//
+// Writes are serialized by the repository owning the table.
+type idTable struct {
+	dense      bool
+	slots      unsafe.Pointer // *[]unsafe.Pointer
+	denseCount int
+	sparse     map[uint]unsafe.Pointer
+	sparseLen  int64
+	count      int64
+	sync.RWMutex
+}
+
+func newIdTable(dense bool) *idTable {
+	slots := make([]unsafe.Pointer, 0)
+	return &idTable{
+		dense:  dense,
+		slots:  unsafe.Pointer(&slots),
+		sparse: make(map[uint]unsafe.Pointer),
+	}
+}
+
+func (t *idTable) denseSlots() []unsafe.Pointer {
+	return *(*[]unsafe.Pointer)(atomic.LoadPointer(&t.slots))
+}
+
+func (t *idTable) loadDense(id uint) unsafe.Pointer {
+	if slots := t.denseSlots(); id < uint(len(slots)) {
+		return atomic.LoadPointer(&slots[id])
+	}
+	return nil
+}
+
+// load returns the entity of the id. A sparse id can move into the slots
+// between the two lookups, so a miss in the map looks at the slots again.
+func (t *idTable) load(id uint) unsafe.Pointer {
+	if p := t.loadDense(id); p != nil {
+		return p
+	}
+	if atomic.LoadInt64(&t.sparseLen) == 0 {
+		return t.loadDense(id)
+	}
+
+	t.RLock()
+	defer t.RUnlock()
+
+	if p := t.sparse[id]; p != nil {
+		return p
+	}
+	return t.loadDense(id)
+}
+
+// store sets the entity of the id, which has to be non-nil.
+func (t *idTable) store(id uint, p unsafe.Pointer) {
+	slots := t.denseSlots()
+	if id < uint(len(slots)) && atomic.LoadPointer(&slots[id]) != nil {
+		atomic.StorePointer(&slots[id], p)
+		return
+	}
+
+	t.Lock()
+	defer t.Unlock()
+
+	if _, ok := t.sparse[id]; ok {
+		t.sparse[id] = p
+		return
+	}
+	if !t.dense || id >= uint(len(slots)) && id >= uint(2*t.denseCount+denseMinLen) {
+		t.sparse[id] = p
+		atomic.AddInt64(&t.sparseLen, 1)
+		atomic.AddInt64(&t.count, 1)
+		return
+	}
+
+	if id >= uint(len(slots)) {
+		size := 2 * len(slots)
+		if size < denseMinLen {
+			size = denseMinLen
+		}
+		if size <= int(id) {
+			size = int(id) + 1
+		}
+		grown := make([]unsafe.Pointer, size)
+		copy(grown, slots)
+		moved := make([]uint, 0)
+		for sparseId, p := range t.sparse {
+			if sparseId < uint(size) {
+				grown[sparseId] = p
+				moved = append(moved, sparseId)
+			}
+		}
+		atomic.StorePointer(&t.slots, unsafe.Pointer(&grown))
+		// The sparse ids the slots grew past are only taken out of the map
+		// once the slots holding them are published.
+		for _, sparseId := range moved {
+			delete(t.sparse, sparseId)
+		}
+		atomic.AddInt64(&t.sparseLen, -int64(len(moved)))
+		t.denseCount += len(moved)
+		slots = grown
+	}
+	atomic.StorePointer(&slots[id], p)
+	t.denseCount++
+	atomic.AddInt64(&t.count, 1)
+}
+
+func (t *idTable) len() int {
+	return int(atomic.LoadInt64(&t.count))
+}
+
+// each calls fn with every entity in the table, the dense ones in the order
+// of their ids.
+func (t *idTable) each(fn func(p unsafe.Pointer)) {
+	slots := t.denseSlots()
+	eachSlot(slots, 0, fn)
+
+	t.RLock()
+	defer t.RUnlock()
+
+	// Sparse ids are always beyond the slots, so the ones that moved into
+	// slots grown meanwhile are in the part not visited yet.
+	eachSlot(t.denseSlots(), len(slots), fn)
+	for _, p := range t.sparse {
+		fn(p)
+	}
+}
+
+func eachSlot(slots []unsafe.Pointer, from int, fn func(p unsafe.Pointer)) {
+	for i := from; i < len(slots); i++ {
+		if p := atomic.LoadPointer(&slots[i]); p != nil {
+			fn(p)
+		}
+	}
+}
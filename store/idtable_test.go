@@ -0,0 +1,148 @@
+package store
+
+import (
+	"strconv"
+	"testing"
+	"unsafe"
+
+	"github.com/disc/highloadcup/model"
+)
+
+func TestIdTable(t *testing.T) {
+	t.Parallel()
+	for _, dense := range []bool{false, true} {
+		table := newIdTable(dense)
+		values := make(map[uint]*int)
+		for _, id := range []uint{1, 2, 1025, 3, 1 << 40, 2} {
+			value := int(id)
+			values[id] = &value
+			table.store(id, unsafe.Pointer(&value))
+		}
+
+		if table.len() != 5 {
+			t.Errorf("dense %v: got %d entities", dense, table.len())
+		}
+		for id, value := range values {
+			if got := (*int)(table.load(id)); got != value {
+				t.Errorf("dense %v: got %v for %d", dense, got, id)
+			}
+		}
+		if table.load(4) != nil || table.load(1<<41) != nil {
+			t.Errorf("dense %v: got an entity never stored", dense)
+		}
+
+		seen := 0
+		table.each(func(p unsafe.Pointer) { seen++ })
+		if seen != 5 {
+			t.Errorf("dense %v: got %d entities in each", dense, seen)
+		}
+
+		// 1025 is close enough to the other ids to get a slot, 1 << 40 is not.
+		slots, sparse := len(table.denseSlots()), len(table.sparse)
+		if !dense && (slots != 0 || sparse != 5) || dense && (slots != 2*denseMinLen || sparse != 1) {
+			t.Errorf("dense %v: got %d slots and %d sparse ids", dense, slots, sparse)
+		}
+	}
+}
+
+// TestIdTableOutOfOrder stores ids the way the loader reads users_1,
+// users_10, users_2 and so on: a block of ids far beyond the others comes
+// early and moves into the slots once they grow past it.
+func TestIdTableOutOfOrder(t *testing.T) {
+	t.Parallel()
+	table := newIdTable(true)
+	values := make(map[uint]*int)
+	store := func(from uint, to uint) {
+		for id := from; id <= to; id++ {
+			value := int(id)
+			values[id] = &value
+			table.store(id, unsafe.Pointer(&value))
+		}
+	}
+
+	store(1, 1000)
+	store(9001, 10000)
+	if sparse := len(table.sparse); sparse != 1000 {
+		t.Errorf("got %d sparse ids before the slots grow", sparse)
+	}
+	store(1001, 9000)
+
+	if sparse := len(table.sparse); sparse != 0 || table.sparseLen != 0 {
+		t.Errorf("got %d sparse ids after the slots grew", sparse)
+	}
+	if table.len() != 10000 || table.denseCount != 10000 {
+		t.Errorf("got %d entities and %d in slots", table.len(), table.denseCount)
+	}
+	for id, value := range values {
+		if got := (*int)(table.load(id)); got != value {
+			t.Errorf("got %v for %d", got, id)
+		}
+	}
+	seen := 0
+	table.each(func(p unsafe.Pointer) { seen++ })
+	if seen != 10000 {
+		t.Errorf("got %d entities in each", seen)
+	}
+}
+
+func benchmarkStore(dense bool, n int) *Store {
+	s := newStore(0, dense)
+	for id := uint(1); id <= uint(n); id++ {
+		s.Users.Update(model.User{Id: id, Email: strconv.Itoa(int(id)) + "@example.com"})
+		s.Locations.Update(model.Location{Id: id})
+		s.Visits.Update(model.Visit{Id: uint32(id), Location: uint32(id), User: uint32(id)})
+	}
+	return s
+}
+
+// benchmarkGet reads entities by id from parallel goroutines. With write set,
+// another goroutine keeps updating visits meanwhile.
+func benchmarkGet(b *testing.B, dense bool, write bool) {
+	const n = 100000
+	s := benchmarkStore(dense, n)
+
+	done := make(chan struct{})
+	defer close(done)
+	if write {
+		go func() {
+			for id := uint(1); ; id = id%n + 1 {
+				select {
+				case <-done:
+					return
+				default:
+					s.Visits.Update(model.Visit{Id: uint32(id), Location: uint32(id), User: uint32(id), Mark: uint8(id % 6)})
+				}
+			}
+		}()
+	}
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		id := uint(1)
+		for pb.Next() {
+			user, location, visit := s.Users.Get(id), s.Locations.Get(id), s.Visits.Get(id)
+			if user == nil || location == nil || visit == nil {
+				b.Errorf("missing entity %d", id)
+			} else if user.Id != id || location.Id != id || uint(visit.User) != id || visit.Mark > 5 {
+				b.Errorf("got the wrong entity for %d", id)
+			}
+			id = id%n + 1
+		}
+	})
+}
+
+func BenchmarkGetMap(b *testing.B) {
+	benchmarkGet(b, false, false)
+}
+
+func BenchmarkGetDense(b *testing.B) {
+	benchmarkGet(b, true, false)
+}
+
+func BenchmarkGetMapWithWriter(b *testing.B) {
+	benchmarkGet(b, false, true)
+}
+
+func BenchmarkGetDenseWithWriter(b *testing.B) {
+	benchmarkGet(b, true, true)
+}
@@ -0,0 +1,23 @@
+package store
+
+func addToIndex(index map[string]map[uint]struct{}, key string, id uint) {
+	if index[key] == nil {
+		index[key] = make(map[uint]struct{})
+	}
+	index[key][id] = struct{}{}
+}
+
+func removeFromIndex(index map[string]map[uint]struct{}, key string, id uint) {
+	delete(index[key], id)
+	if len(index[key]) == 0 {
+		delete(index, key)
+	}
+}
+
+// addIdToIndex is addToIndex for indexes keyed by another entity's id.
+func addIdToIndex(index map[uint]map[uint]struct{}, key uint, id uint) {
+	if index[key] == nil {
+		index[key] = make(map[uint]struct{})
+	}
+	index[key][id] = struct{}{}
+}
@@ -0,0 +1,141 @@
+package store
+
+import (
+	"sync"
+	"unsafe"
+
+	"github.com/disc/highloadcup/model"
+)
+
+type LocationsMap struct {
+	locations   *idTable
+	history     map[uint][]model.Location
+	historySize int
+	byCountry   map[string]map[uint]struct{}
+	byCity      map[string]map[uint]struct{}
+	text        *textIndex
+	observer    Observer
+	sync.RWMutex
+}
+
+func newLocationsMap(historySize int, dense bool) *LocationsMap {
+	return &LocationsMap{
+		locations:   newIdTable(dense),
+		history:     make(map[uint][]model.Location),
+		historySize: historySize,
+		byCountry:   make(map[string]map[uint]struct{}),
+		byCity:      make(map[string]map[uint]struct{}),
+		text:        newTextIndex(),
+	}
+}
+
+func (l *LocationsMap) Get(id uint) *model.Location {
+	return (*model.Location)(l.locations.load(id))
+}
+
+func (l *LocationsMap) Len() int {
+	return l.locations.len()
+}
+
+func (l *LocationsMap) each(fn func(location *model.Location)) {
+	l.locations.each(func(p unsafe.Pointer) { fn((*model.Location)(p)) })
+}
+
+// GetAsOf returns the revision of the location that was current at the given
+// unix time, or nil if it did not exist yet or has aged out of the history.
+func (l *LocationsMap) GetAsOf(id uint, at int) *model.Location {
+	l.RLock()
+	defer l.RUnlock()
+
+	location := l.Get(id)
+	if location == nil || location.UpdatedAt <= at {
+		return location
+	}
+	revisions := l.history[id]
+	for i := len(revisions) - 1; i >= 0; i-- {
+		if revisions[i].UpdatedAt <= at {
+			revision := revisions[i]
+			return &revision
+		}
+	}
+	return nil
+}
+
+// Ids returns the ids of the locations in the country, or of all locations
+// when country is nil.
+func (l *LocationsMap) Ids(country *string) []uint {
+	l.RLock()
+	defer l.RUnlock()
+
+	if country != nil {
+		ids := make([]uint, 0, len(l.byCountry[*country]))
+		for id := range l.byCountry[*country] {
+			ids = append(ids, id)
+		}
+		return ids
+	}
+	ids := make([]uint, 0, l.locations.len())
+	l.each(func(location *model.Location) {
+		ids = append(ids, location.Id)
+	})
+	return ids
+}
+
+// Update stores the location and returns its new version along with the
+// location it replaced, if any.
+func (l *LocationsMap) Update(location model.Location) (uint, *model.Location) {
+	l.Lock()
+	defer l.Unlock()
+
+	return l.update(location)
+}
+
+// UpdateIfVersion stores the location only if the stored one is still at the
+// given version, and returns the location it replaced or ErrVersionMismatch.
+func (l *LocationsMap) UpdateIfVersion(location model.Location, version uint) (*model.Location, error) {
+	l.Lock()
+	defer l.Unlock()
+
+	if prev := l.Get(location.Id); prev == nil || prev.Version != version {
+		return nil, ErrVersionMismatch
+	}
+	_, prev := l.update(location)
+	return prev, nil
+}
+
+func (l *LocationsMap) update(location model.Location) (uint, *model.Location) {
+	location.Version = 1
+	prev := l.Get(location.Id)
+	if prev != nil {
+		location.Version = prev.Version + 1
+		if l.historySize > 0 {
+			revisions := append(l.history[location.Id], *prev)
+			if len(revisions) > l.historySize {
+				revisions = revisions[len(revisions)-l.historySize:]
+			}
+			l.history[location.Id] = revisions
+		}
+		removeFromIndex(l.byCountry, prev.Country, prev.Id)
+		removeFromIndex(l.byCity, prev.City, prev.Id)
+		l.text.remove(prev)
+	}
+	l.locations.store(location.Id, unsafe.Pointer(&location))
+	addToIndex(l.byCountry, location.Country, location.Id)
+	addToIndex(l.byCity, location.City, location.Id)
+	l.text.add(&location)
+	if l.observer != nil {
+		if prev != nil {
+			l.observer("location", location.Id, location.Version, location, *prev)
+		} else {
+			l.observer("location", location.Id, location.Version, location, nil)
+		}
+	}
+	return location.Version, prev
+}
+
+func (l *LocationsMap) Observe(observer Observer) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.observer = observer
+}
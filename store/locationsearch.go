@@ -0,0 +1,95 @@
+package store
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/disc/highloadcup/model"
+)
+
+// LocationSearchFilter narrows GET /locations. Place is matched as a
+// substring and has to be lowercased; Sort is id, distance or avg.
+type LocationSearchFilter struct {
+	Country      *string
+	City         *string
+	Place        *string
+	FromDistance *uint
+	ToDistance   *uint
+
+	Sort string
+	Page Page
+}
+
+func (filter *LocationSearchFilter) matches(location *model.Location) bool {
+	if filter.Country != nil && location.Country != *filter.Country {
+		return false
+	}
+	if filter.City != nil && location.City != *filter.City {
+		return false
+	}
+	if filter.Place != nil && !strings.Contains(strings.ToLower(location.Place), *filter.Place) {
+		return false
+	}
+	if filter.FromDistance != nil && location.Distance < *filter.FromDistance {
+		return false
+	}
+	if filter.ToDistance != nil && location.Distance > *filter.ToDistance {
+		return false
+	}
+	return true
+}
+
+// Search returns the page of locations matching the filter and the total
+// number of matches. Country and city filters are served from the indexes,
+// avg gives the average mark of a location for the avg sort.
+func (l *LocationsMap) Search(filter LocationSearchFilter, avg func(id uint) float64) ([]model.Location, int) {
+	matches := make([]*model.Location, 0)
+
+	l.RLock()
+	var candidates map[uint]struct{}
+	if filter.Country != nil {
+		candidates = l.byCountry[*filter.Country]
+	}
+	if filter.City != nil && (filter.Country == nil || len(l.byCity[*filter.City]) < len(candidates)) {
+		candidates = l.byCity[*filter.City]
+	}
+	if filter.Country != nil || filter.City != nil {
+		for id := range candidates {
+			if location := l.Get(id); filter.matches(location) {
+				matches = append(matches, location)
+			}
+		}
+	} else {
+		l.each(func(location *model.Location) {
+			if filter.matches(location) {
+				matches = append(matches, location)
+			}
+		})
+	}
+	l.RUnlock()
+
+	switch filter.Sort {
+	case "distance":
+		sort.Slice(matches, func(i, j int) bool {
+			return matches[i].Distance < matches[j].Distance ||
+				matches[i].Distance == matches[j].Distance && matches[i].Id < matches[j].Id
+		})
+	case "avg":
+		avgs := make(map[uint]float64, len(matches))
+		for _, location := range matches {
+			avgs[location.Id] = avg(location.Id)
+		}
+		sort.Slice(matches, func(i, j int) bool {
+			a, b := avgs[matches[i].Id], avgs[matches[j].Id]
+			return a < b || a == b && matches[i].Id < matches[j].Id
+		})
+	default:
+		sort.Slice(matches, func(i, j int) bool { return matches[i].Id < matches[j].Id })
+	}
+
+	page := make([]model.Location, 0)
+	for i := 0; filter.Page.index(i, len(matches)) != -1; i++ {
+		page = append(page, *matches[filter.Page.index(i, len(matches))])
+	}
+	return page, len(matches)
+}
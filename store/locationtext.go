@@ -0,0 +1,188 @@
+package store
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/disc/highloadcup/model"
+)
+
+const (
+	textMatchExact  = 3
+	textMatchPrefix = 2
+	textMatchFuzzy  = 1
+)
+
+// textIndex is an inverted index from the lowercased words of the place,
+// city and country of every location to the location ids. Terms are also
+// kept sorted to find the ones starting with a prefix.
+type textIndex struct {
+	postings map[string]map[uint]struct{}
+	terms    []string
+}
+
+func newTextIndex() *textIndex {
+	return &textIndex{postings: make(map[string]map[uint]struct{})}
+}
+
+// Tokenize splits the text into lowercased words of letters and digits.
+// Ё is folded into Е as it is often typed without the diaeresis.
+func Tokenize(text string) []string {
+	text = strings.Map(func(r rune) rune {
+		if r = unicode.ToLower(r); r == 'ё' {
+			return 'е'
+		}
+		return r
+	}, text)
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func locationTokens(location *model.Location) []string {
+	return Tokenize(location.Place + " " + location.City + " " + location.Country)
+}
+
+func (t *textIndex) add(location *model.Location) {
+	for _, term := range locationTokens(location) {
+		if t.postings[term] == nil {
+			i := sort.SearchStrings(t.terms, term)
+			t.terms = append(t.terms, "")
+			copy(t.terms[i+1:], t.terms[i:])
+			t.terms[i] = term
+		}
+		addToIndex(t.postings, term, location.Id)
+	}
+}
+
+func (t *textIndex) remove(location *model.Location) {
+	for _, term := range locationTokens(location) {
+		if t.postings[term] == nil {
+			continue
+		}
+		removeFromIndex(t.postings, term, location.Id)
+		if len(t.postings[term]) == 0 {
+			delete(t.postings, term)
+			i := sort.SearchStrings(t.terms, term)
+			t.terms = append(t.terms[:i], t.terms[i+1:]...)
+		}
+	}
+}
+
+// maxEdits is how many typos a query word of the given length may have.
+func maxEdits(word []rune) int {
+	switch {
+	case len(word) <= 3:
+		return 0
+	case len(word) <= 6:
+		return 1
+	}
+	return 2
+}
+
+// levenshtein returns the edit distance between a and b, giving up with
+// max+1 as soon as it is known to exceed max.
+func levenshtein(a, b []rune, max int) int {
+	if d := len(a) - len(b); d > max || -d > max {
+		return max + 1
+	}
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		rowMin := i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = prev[j-1] + cost
+			if prev[j]+1 < curr[j] {
+				curr[j] = prev[j] + 1
+			}
+			if curr[j-1]+1 < curr[j] {
+				curr[j] = curr[j-1] + 1
+			}
+			if curr[j] < rowMin {
+				rowMin = curr[j]
+			}
+		}
+		if rowMin > max {
+			return max + 1
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+// match scores every location having a term equal to the word, starting
+// with it or within maxEdits typos of it, keeping the best kind of match.
+func (t *textIndex) match(word string) map[uint]int {
+	scores := make(map[uint]int)
+	score := func(term string, kind int) {
+		for id := range t.postings[term] {
+			if scores[id] < kind {
+				scores[id] = kind
+			}
+		}
+	}
+
+	for i := sort.SearchStrings(t.terms, word); i < len(t.terms) && strings.HasPrefix(t.terms[i], word); i++ {
+		if t.terms[i] == word {
+			score(t.terms[i], textMatchExact)
+		} else {
+			score(t.terms[i], textMatchPrefix)
+		}
+	}
+	runes := []rune(word)
+	if edits := maxEdits(runes); edits > 0 {
+		for _, term := range t.terms {
+			if term != word && levenshtein(runes, []rune(term), edits) <= edits {
+				score(term, textMatchFuzzy)
+			}
+		}
+	}
+	return scores
+}
+
+// TextSearch returns the page of locations matching every word of the
+// query, the best matches first, and the total number of matches.
+func (l *LocationsMap) TextSearch(query string, page Page) ([]model.Location, int) {
+	var scores map[uint]int
+
+	l.RLock()
+	for _, word := range Tokenize(query) {
+		wordScores := l.text.match(word)
+		if scores == nil {
+			scores = wordScores
+			continue
+		}
+		for id, score := range scores {
+			if wordScore, ok := wordScores[id]; ok {
+				scores[id] = score + wordScore
+			} else {
+				delete(scores, id)
+			}
+		}
+	}
+	matches := make([]*model.Location, 0, len(scores))
+	for id := range scores {
+		matches = append(matches, l.Get(id))
+	}
+	l.RUnlock()
+
+	sort.Slice(matches, func(i, j int) bool {
+		a, b := scores[matches[i].Id], scores[matches[j].Id]
+		return a > b || a == b && matches[i].Id < matches[j].Id
+	})
+
+	locations := make([]model.Location, 0)
+	for i := 0; page.index(i, len(matches)) != -1; i++ {
+		locations = append(locations, *matches[page.index(i, len(matches))])
+	}
+	return locations, len(matches)
+}
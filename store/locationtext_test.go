@@ -0,0 +1,37 @@
+package store
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/disc/highloadcup/model"
+)
+
+func TestTextIndex(t *testing.T) {
+	index := newTextIndex()
+	index.add(&model.Location{Id: 1, Place: "Ёлочный базар", City: "Санкт-Петербург", Country: "Россия"})
+	index.add(&model.Location{Id: 2, Place: "Ресторан", City: "Москва", Country: "Россия"})
+
+	tests := []struct {
+		word     string
+		expected map[uint]int
+	}{
+		{"россия", map[uint]int{1: textMatchExact, 2: textMatchExact}},
+		{"елоч", map[uint]int{1: textMatchPrefix}},
+		{"петербрг", map[uint]int{1: textMatchFuzzy}},
+		{"мск", map[uint]int{}},
+	}
+	for _, test := range tests {
+		if scores := index.match(test.word); !reflect.DeepEqual(scores, test.expected) {
+			t.Errorf("match(%q) = %v, want %v", test.word, scores, test.expected)
+		}
+	}
+
+	index.remove(&model.Location{Id: 2, Place: "Ресторан", City: "Москва", Country: "Россия"})
+	if scores := index.match("ресторан"); len(scores) != 0 {
+		t.Errorf("removed location still matches: %v", scores)
+	}
+	if !reflect.DeepEqual(index.terms, []string{"базар", "елочный", "петербург", "россия", "санкт"}) {
+		t.Errorf("unexpected terms %v", index.terms)
+	}
+}
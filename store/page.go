@@ -0,0 +1,20 @@
+package store
+
+// Page is the offset/limit/order part of a listing request.
+type Page struct {
+	Offset int
+	Limit  int
+	Desc   bool
+}
+
+// index maps the i-th position of the page onto the ascending order of
+// total results, or returns -1 past the end.
+func (p Page) index(i int, total int) int {
+	if i >= p.Limit || p.Offset+i >= total {
+		return -1
+	}
+	if p.Desc {
+		return total - 1 - p.Offset - i
+	}
+	return p.Offset + i
+}
@@ -0,0 +1,49 @@
+package store
+
+import "github.com/disc/highloadcup/model"
+
+// UserRepository stores the users. UsersMap keeps them in memory only,
+// OpenFileStore also keeps them in a file.
+type UserRepository interface {
+	Get(id uint) *model.User
+	GetAsOf(id uint, at int) *model.User
+	Len() int
+	EmailOwner(email string) (uint, bool)
+	Validate(user *model.User) error
+	Search(filter UserSearchFilter) ([]model.User, int)
+	Update(user model.User) (uint, *model.User)
+	UpdateIfVersion(user model.User, version uint) (*model.User, error)
+	UpdateUnique(user model.User, version *uint) (*model.User, error)
+	Observe(observer Observer)
+}
+
+// LocationRepository stores the locations. LocationsMap keeps them in memory
+// only, OpenFileStore also keeps them in a file.
+type LocationRepository interface {
+	Get(id uint) *model.Location
+	GetAsOf(id uint, at int) *model.Location
+	Len() int
+	Ids(country *string) []uint
+	Search(filter LocationSearchFilter, avg func(id uint) float64) ([]model.Location, int)
+	TextSearch(query string, page Page) ([]model.Location, int)
+	Update(location model.Location) (uint, *model.Location)
+	UpdateIfVersion(location model.Location, version uint) (*model.Location, error)
+	Observe(observer Observer)
+}
+
+// VisitRepository stores the visits. VisitsMap keeps them in memory only,
+// OpenFileStore also keeps them in a file.
+type VisitRepository interface {
+	Get(id uint) *model.Visit
+	GetAsOf(id uint, at int) *model.Visit
+	Len() int
+	ByUser(userId uint) []*model.Visit
+	ByLocation(locationId uint) []*model.Visit
+	LocationMarks(locationId uint) (uint, uint)
+	History(id uint) []model.Visit
+	UserVisitsAsOf(userId uint, at int) []*model.Visit
+	Generation() uint64
+	Update(visit model.Visit) (uint, *model.Visit)
+	UpdateIfVersion(visit model.Visit, version uint) (*model.Visit, error)
+	Observe(observer Observer)
+}
@@ -0,0 +1,348 @@
+package store
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/disc/highloadcup/model"
+)
+
+// repositories opens an empty store of every implementation, the file one
+// in dir. The tests below run against each of them.
+var repositories = map[string]func(t *testing.T, dir string) *Store{
+	"memory": func(t *testing.T, dir string) *Store {
+		return NewStore(4)
+	},
+	"dense": func(t *testing.T, dir string) *Store {
+		return NewDenseStore(4)
+	},
+	"file": func(t *testing.T, dir string) *Store {
+		s, err := OpenFileStore(dir, NewStore(4))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return s
+	},
+}
+
+func tempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "store")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func testUser(id uint) model.User {
+	return model.User{Id: id, Email: "user@example.com", First_name: "Анна", Last_name: "Иванова", Gender: "f", Birth_date: 0}
+}
+
+func TestUserRepository(t *testing.T) {
+	t.Parallel()
+	for name, open := range repositories {
+		open := open
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			dir := tempDir(t)
+			defer os.RemoveAll(dir)
+			s := open(t, dir)
+			defer s.Close()
+			users := s.Users
+
+			if users.Get(1) != nil || users.Len() != 0 {
+				t.Fatal("expected an empty repository")
+			}
+			user := testUser(1)
+			user.UpdatedAt = 100
+			if version, prev := users.Update(user); version != 1 || prev != nil {
+				t.Fatalf("got version %d and %v on create", version, prev)
+			}
+			user.First_name = "Мария"
+			user.UpdatedAt = 200
+			if version, prev := users.Update(user); version != 2 || prev == nil || prev.First_name != "Анна" {
+				t.Fatalf("got version %d and %v on update", version, prev)
+			}
+			if got := users.Get(1); got == nil || got.First_name != "Мария" || got.Version != 2 || users.Len() != 1 {
+				t.Fatalf("got %v", got)
+			}
+			if got := users.GetAsOf(1, 150); got == nil || got.First_name != "Анна" {
+				t.Errorf("got %v as of 150", got)
+			}
+			if got := users.GetAsOf(1, 50); got != nil {
+				t.Errorf("got %v before the user existed", got)
+			}
+
+			user.Last_name = "Петрова"
+			if prev, err := users.UpdateIfVersion(user, 1); prev != nil || err != ErrVersionMismatch || users.Get(1).Last_name != "Иванова" {
+				t.Error("stored on a version mismatch")
+			}
+			if prev, err := users.UpdateIfVersion(user, 2); prev == nil || err != nil || users.Get(1).Last_name != "Петрова" {
+				t.Error("not stored on a matching version")
+			}
+
+			if owner, ok := users.EmailOwner("user@example.com"); !ok || owner != 1 {
+				t.Errorf("got owner %d, %v", owner, ok)
+			}
+			other := testUser(2)
+			if users.Validate(&other) == nil {
+				t.Error("accepted a taken email")
+			}
+			other.Email = "other@example.com"
+			if err := users.Validate(&other); err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if _, ok := users.EmailOwner("other@example.com"); ok {
+				t.Error("email taken before the user was stored")
+			}
+			if _, err := users.UpdateUnique(testUser(2), nil); err == nil {
+				t.Error("stored a taken email")
+			}
+			var created uint
+			if prev, err := users.UpdateUnique(other, &created); err != nil || prev != nil {
+				t.Fatalf("got %v and %v on create", prev, err)
+			}
+			if _, err := users.UpdateUnique(other, &created); err != ErrVersionMismatch {
+				t.Errorf("got %v on a second create", err)
+			}
+			if owner, ok := users.EmailOwner("other@example.com"); !ok || owner != 2 {
+				t.Errorf("got owner %d, %v", owner, ok)
+			}
+
+			lastName := "петр"
+			found, total := users.Search(UserSearchFilter{LastName: &lastName, Sort: "id", Page: Page{Limit: 10}})
+			if total != 1 || len(found) != 1 || found[0].Id != 1 {
+				t.Errorf("got %v of %d", found, total)
+			}
+		})
+	}
+}
+
+func TestLocationRepository(t *testing.T) {
+	t.Parallel()
+	for name, open := range repositories {
+		open := open
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			dir := tempDir(t)
+			defer os.RemoveAll(dir)
+			s := open(t, dir)
+			defer s.Close()
+			locations := s.Locations
+
+			locations.Update(model.Location{Id: 1, Place: "Набережная", Country: "Россия", City: "Москва", Distance: 10})
+			locations.Update(model.Location{Id: 2, Place: "Пляж", Country: "Италия", City: "Рим", Distance: 20})
+			if version, prev := locations.Update(model.Location{Id: 2, Place: "Парк", Country: "Россия", City: "Москва", Distance: 30, UpdatedAt: 100}); version != 2 || prev == nil || prev.Country != "Италия" {
+				t.Fatalf("got version %d and %v on update", version, prev)
+			}
+			if locations.Len() != 2 {
+				t.Errorf("got %d locations", locations.Len())
+			}
+			if got := locations.GetAsOf(2, 50); got == nil || got.Place != "Пляж" {
+				t.Errorf("got %v as of 50", got)
+			}
+
+			country := "Россия"
+			if ids := locations.Ids(&country); len(ids) != 2 {
+				t.Errorf("got %v in %s", ids, country)
+			}
+			country = "Италия"
+			if ids := locations.Ids(&country); len(ids) != 0 {
+				t.Errorf("got %v in %s", ids, country)
+			}
+			if ids := locations.Ids(nil); len(ids) != 2 {
+				t.Errorf("got %v", ids)
+			}
+
+			if prev, err := locations.UpdateIfVersion(model.Location{Id: 1, Place: "Сад"}, 2); prev != nil || err != ErrVersionMismatch {
+				t.Error("stored on a version mismatch")
+			}
+
+			avg := func(id uint) float64 { return 0 }
+			found, total := locations.Search(LocationSearchFilter{Sort: "distance", Page: Page{Limit: 10, Desc: true}}, avg)
+			if total != 2 || found[0].Id != 2 || found[1].Id != 1 {
+				t.Errorf("got %v of %d", found, total)
+			}
+			found, total = locations.TextSearch("парк", Page{Limit: 10})
+			if total != 1 || found[0].Id != 2 {
+				t.Errorf("got %v of %d", found, total)
+			}
+		})
+	}
+}
+
+func TestVisitRepository(t *testing.T) {
+	t.Parallel()
+	for name, open := range repositories {
+		open := open
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			dir := tempDir(t)
+			defer os.RemoveAll(dir)
+			s := open(t, dir)
+			defer s.Close()
+			visits := s.Visits
+
+			generation := visits.Generation()
+			visits.Update(model.Visit{Id: 1, Location: 10, User: 20, Visited_at: 1000, Mark: 3, UpdatedAt: 100})
+			visits.Update(model.Visit{Id: 2, Location: 10, User: 21, Visited_at: 1000, Mark: 4, UpdatedAt: 100})
+			if visits.Generation() == generation {
+				t.Error("generation did not move on")
+			}
+			if version, prev := visits.Update(model.Visit{Id: 1, Location: 11, User: 21, Visited_at: 1000, Mark: 5, UpdatedAt: 200}); version != 2 || prev == nil || prev.User != 20 {
+				t.Fatalf("got version %d and %v on update", version, prev)
+			}
+			if visits.Len() != 2 || visits.Get(1).Mark != 5 {
+				t.Errorf("got %v of %d", visits.Get(1), visits.Len())
+			}
+			if got := visits.ByUser(20); len(got) != 0 {
+				t.Errorf("got %v for the previous user", got)
+			}
+			if got := visits.ByUser(21); len(got) != 2 {
+				t.Errorf("got %v for the user", got)
+			}
+			if got := visits.ByLocation(10); len(got) != 1 || got[0].Id != 2 {
+				t.Errorf("got %v for the previous location", got)
+			}
+			if got := visits.ByLocation(11); len(got) != 1 || got[0].Id != 1 {
+				t.Errorf("got %v for the location", got)
+			}
+
+			if history := visits.History(1); len(history) != 2 || history[0].Mark != 3 || history[1].Mark != 5 {
+				t.Errorf("got history %v", history)
+			}
+			if got := visits.GetAsOf(1, 150); got == nil || got.Mark != 3 {
+				t.Errorf("got %v as of 150", got)
+			}
+			if got := visits.UserVisitsAsOf(20, 150); len(got) != 1 || got[0].Id != 1 {
+				t.Errorf("got %v for the user as of 150", got)
+			}
+
+			if prev, err := visits.UpdateIfVersion(model.Visit{Id: 2, Location: 10, User: 21, Mark: 1}, 2); prev != nil || err != ErrVersionMismatch || visits.Get(2).Mark != 4 {
+				t.Error("stored on a version mismatch")
+			}
+			if prev, err := visits.UpdateIfVersion(model.Visit{Id: 2, Location: 10, User: 21, Mark: 1}, 1); prev == nil || err != nil || visits.Get(2).Mark != 1 {
+				t.Error("not stored on a matching version")
+			}
+		})
+	}
+}
+
+func TestFileStoreReopen(t *testing.T) {
+	t.Parallel()
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	s, err := OpenFileStore(dir, NewDenseStore(4))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Users.Update(testUser(1))
+	s.Locations.Update(model.Location{Id: 1, Place: "Парк", Country: "Россия", City: "Москва", Distance: 10})
+	s.Visits.Update(model.Visit{Id: 1, Location: 1, User: 1, Visited_at: 1000, Mark: 3, UpdatedAt: 100})
+	s.Visits.Update(model.Visit{Id: 1, Location: 1, User: 1, Visited_at: 1000, Mark: 5, UpdatedAt: 200})
+	s.Close()
+
+	// A record cut off by a crash is dropped.
+	file, err := os.OpenFile(filepath.Join(dir, "visits.jsonl"), os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file.WriteString(`{"updated_at":300,"data":{"id":2`)
+	file.Close()
+
+	s, err = OpenFileStore(dir, NewDenseStore(4))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user := s.Users.Get(1); user == nil || user.Email != "user@example.com" {
+		t.Errorf("got user %v", user)
+	}
+	if owner, ok := s.Users.EmailOwner("user@example.com"); !ok || owner != 1 {
+		t.Errorf("got email owner %d, %v", owner, ok)
+	}
+	if location := s.Locations.Get(1); location == nil || location.Place != "Парк" {
+		t.Errorf("got location %v", location)
+	}
+	visit := s.Visits.Get(1)
+	if visit == nil || visit.Mark != 5 || visit.Version != 2 || visit.UpdatedAt != 200 {
+		t.Fatalf("got visit %v", visit)
+	}
+	if got := s.Visits.GetAsOf(1, 150); got == nil || got.Mark != 3 {
+		t.Errorf("got %v as of 150", got)
+	}
+	if s.Visits.Len() != 1 || len(s.Visits.ByLocation(1)) != 1 {
+		t.Errorf("got %d visits", s.Visits.Len())
+	}
+
+	s.Visits.Update(model.Visit{Id: 2, Location: 1, User: 1, Visited_at: 1000, Mark: 4, UpdatedAt: 400})
+	s.Close()
+	if s, err = OpenFileStore(dir, NewDenseStore(4)); err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	if visit := s.Visits.Get(2); visit == nil || visit.Mark != 4 {
+		t.Errorf("got visit %v after the dropped record", visit)
+	}
+}
+
+func TestFileStoreRefusesFailedWrites(t *testing.T) {
+	t.Parallel()
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	s, err := OpenFileStore(dir, NewStore(4))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Users.Update(testUser(1))
+	s.Visits.Update(model.Visit{Id: 1, Location: 1, User: 1, Mark: 3})
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Writes to the closed files fail and are not applied.
+	if version, prev := s.Users.Update(testUser(2)); version != 0 || prev != nil || s.Users.Get(2) != nil {
+		t.Errorf("got version %d for a failed create", version)
+	}
+	unique := testUser(3)
+	unique.Email = "unique@example.com"
+	if _, err := s.Users.UpdateUnique(unique, nil); err == nil || err == ErrVersionMismatch || s.Users.Get(3) != nil {
+		t.Errorf("got %v for a failed create", err)
+	} else if _, ok := err.(*model.ValidationError); ok {
+		t.Errorf("got %v instead of the write error", err)
+	}
+	if prev, err := s.Visits.UpdateIfVersion(model.Visit{Id: 1, Location: 1, User: 1, Mark: 5}, 1); prev != nil || err == nil || err == ErrVersionMismatch || s.Visits.Get(1).Mark != 3 {
+		t.Errorf("got %v for a failed update", err)
+	}
+	if version, _ := s.Locations.Update(model.Location{Id: 1}); version != 0 || s.Locations.Get(1) != nil {
+		t.Errorf("got version %d for a failed create", version)
+	}
+}
+
+func TestFileStoreLoadedMarker(t *testing.T) {
+	t.Parallel()
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	s, err := OpenFileStore(dir, NewStore(4))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	s.Users.Update(testUser(1))
+
+	if FileStoreLoaded(dir) {
+		t.Fatal("loaded before the marker was written")
+	}
+	if err := MarkFileStoreLoaded(dir, s); err != nil {
+		t.Fatal(err)
+	}
+	if !FileStoreLoaded(dir) {
+		t.Fatal("not loaded after the marker was written")
+	}
+	if err := RemoveFileStore(dir); err != nil {
+		t.Fatal(err)
+	}
+	if files, _ := ioutil.ReadDir(dir); FileStoreLoaded(dir) || len(files) != 0 {
+		t.Errorf("got %d files after removing the store", len(files))
+	}
+}
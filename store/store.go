@@ -0,0 +1,78 @@
+// Package store keeps the entities in memory along with the indexes the
+// queries run on.
+package store
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+)
+
+// Observer is told about every write while the repository still holds its
+// write lock, so the writes of an entity reach it in the order of their
+// versions. prev is nil for a created entity.
+type Observer func(entity string, id uint, version uint, data json.Marshaler, prev json.Marshaler)
+
+// Store owns the entities of one server along with their indexes, and the
+// time the ages of users are counted from.
+type Store struct {
+	Users     UserRepository
+	Locations LocationRepository
+	Visits    VisitRepository
+	Now       int
+}
+
+func NewStore(historySize int) *Store {
+	return newStore(historySize, false)
+}
+
+// NewDenseStore keeps the entities in slices indexed by id, which the dense
+// ids of the contest data fill without gaps, so they are read without locks.
+func NewDenseStore(historySize int) *Store {
+	return newStore(historySize, true)
+}
+
+func newStore(historySize int, dense bool) *Store {
+	return &Store{
+		Users:     newUsersMap(historySize, dense),
+		Locations: newLocationsMap(historySize, dense),
+		Visits:    newVisitsMap(historySize, dense),
+		Now:       int(time.Now().Unix()),
+	}
+}
+
+// Observe has every write of the store reported to the observer, replacing
+// the previous one.
+func (s *Store) Observe(observer Observer) {
+	s.Users.Observe(observer)
+	s.Locations.Observe(observer)
+	s.Visits.Observe(observer)
+}
+
+// Sync flushes the files of a store opened with OpenFileStore to disk.
+func (s *Store) Sync() error {
+	for _, repository := range []interface{}{s.Users, s.Locations, s.Visits} {
+		if syncer, ok := repository.(interface {
+			Sync() error
+		}); ok {
+			if err := syncer.Sync(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Close flushes the files of a store opened with OpenFileStore to disk and
+// closes them.
+func (s *Store) Close() error {
+	var err error
+	for _, repository := range []interface{}{s.Users, s.Locations, s.Visits} {
+		if closer, ok := repository.(io.Closer); ok {
+			if closeErr := closer.Close(); closeErr != nil {
+				err = closeErr
+			}
+		}
+	}
+	return err
+}
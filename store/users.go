@@ -0,0 +1,178 @@
+package store
+
+import (
+	"errors"
+	"sync"
+	"unsafe"
+
+	"github.com/disc/highloadcup/model"
+)
+
+// ErrVersionMismatch is returned by UpdateUnique and UpdateIfVersion when the
+// stored entity is not at the expected version.
+var ErrVersionMismatch = errors.New("Version mismatch")
+
+type UsersMap struct {
+	users       *idTable
+	history     map[uint][]model.User
+	historySize int
+	byEmail     map[string]uint
+	index       *usersIndex
+	observer    Observer
+	sync.RWMutex
+}
+
+func newUsersMap(historySize int, dense bool) *UsersMap {
+	return &UsersMap{
+		users:       newIdTable(dense),
+		history:     make(map[uint][]model.User),
+		historySize: historySize,
+		byEmail:     make(map[string]uint),
+	}
+}
+
+func (u *UsersMap) Get(id uint) *model.User {
+	return (*model.User)(u.users.load(id))
+}
+
+func (u *UsersMap) Len() int {
+	return u.users.len()
+}
+
+func (u *UsersMap) each(fn func(user *model.User)) {
+	u.users.each(func(p unsafe.Pointer) { fn((*model.User)(p)) })
+}
+
+// EmailOwner returns the id of the user with the email, if there is one.
+func (u *UsersMap) EmailOwner(email string) (uint, bool) {
+	u.RLock()
+	defer u.RUnlock()
+
+	id, ok := u.byEmail[email]
+	return id, ok
+}
+
+// Validate checks the user against the model rules and that no other user
+// has the email. UpdateUnique checks the email again when storing the user.
+func (u *UsersMap) Validate(user *model.User) error {
+	var validation model.ValidationError
+	if err := model.ValidateUser(user); err != nil {
+		validation = *err.(*model.ValidationError)
+	}
+	if owner, ok := u.EmailOwner(user.Email); ok && owner != user.Id {
+		validation.Add("email", "is already taken")
+	}
+	return validation.Err()
+}
+
+// GetAsOf returns the revision of the user that was current at the given
+// unix time, or nil if it did not exist yet or has aged out of the history.
+func (u *UsersMap) GetAsOf(id uint, at int) *model.User {
+	u.RLock()
+	defer u.RUnlock()
+
+	user := u.Get(id)
+	if user == nil || user.UpdatedAt <= at {
+		return user
+	}
+	revisions := u.history[id]
+	for i := len(revisions) - 1; i >= 0; i-- {
+		if revisions[i].UpdatedAt <= at {
+			revision := revisions[i]
+			return &revision
+		}
+	}
+	return nil
+}
+
+// Update stores the user and returns its new version along with the
+// user it replaced, if any.
+func (u *UsersMap) Update(user model.User) (uint, *model.User) {
+	u.Lock()
+	defer u.Unlock()
+
+	return u.update(user)
+}
+
+// UpdateUnique stores the user unless another user has its email, checking
+// and storing under the same lock, and returns the user it replaced. With a
+// version the stored user also has to be still at it, where 0 stands for no
+// user with the id yet.
+func (u *UsersMap) UpdateUnique(user model.User, version *uint) (*model.User, error) {
+	u.Lock()
+	defer u.Unlock()
+
+	if err := u.checkUnique(&user, version); err != nil {
+		return nil, err
+	}
+	_, prev := u.update(user)
+	return prev, nil
+}
+
+func (u *UsersMap) checkUnique(user *model.User, version *uint) error {
+	if version != nil {
+		if prev := u.Get(user.Id); prev == nil && *version != 0 || prev != nil && prev.Version != *version {
+			return ErrVersionMismatch
+		}
+	}
+	if owner, ok := u.byEmail[user.Email]; ok && owner != user.Id {
+		var validation model.ValidationError
+		validation.Add("email", "is already taken")
+		return &validation
+	}
+	return nil
+}
+
+// UpdateIfVersion stores the user only if the stored one is still at the
+// given version, and returns the user it replaced or ErrVersionMismatch.
+func (u *UsersMap) UpdateIfVersion(user model.User, version uint) (*model.User, error) {
+	u.Lock()
+	defer u.Unlock()
+
+	if prev := u.Get(user.Id); prev == nil || prev.Version != version {
+		return nil, ErrVersionMismatch
+	}
+	_, prev := u.update(user)
+	return prev, nil
+}
+
+func (u *UsersMap) update(user model.User) (uint, *model.User) {
+	user.Version = 1
+	prev := u.Get(user.Id)
+	if prev != nil {
+		user.Version = prev.Version + 1
+		if u.historySize > 0 {
+			revisions := append(u.history[user.Id], *prev)
+			if len(revisions) > u.historySize {
+				revisions = revisions[len(revisions)-u.historySize:]
+			}
+			u.history[user.Id] = revisions
+		}
+		if u.index != nil {
+			u.index.remove(prev)
+		}
+		if u.byEmail[prev.Email] == prev.Id {
+			delete(u.byEmail, prev.Email)
+		}
+	}
+	u.users.store(user.Id, unsafe.Pointer(&user))
+	u.byEmail[user.Email] = user.Id
+	if u.index != nil {
+		u.index.add(&user)
+	}
+	if u.observer != nil {
+		if prev != nil {
+			u.observer("user", user.Id, user.Version, user, *prev)
+		} else {
+			u.observer("user", user.Id, user.Version, user, nil)
+		}
+	}
+	return user.Version, prev
+}
+
+func (u *UsersMap) Observe(observer Observer) {
+	u.Lock()
+	defer u.Unlock()
+
+	u.observer = observer
+}
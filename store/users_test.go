@@ -0,0 +1,46 @@
+package store
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/disc/highloadcup/model"
+)
+
+func TestValidateTakenEmail(t *testing.T) {
+	t.Parallel()
+	users := newUsersMap(0, false)
+	valid := model.User{Id: 900000200, Email: "valid@example.com", First_name: "Анна", Last_name: "Б", Gender: "f", Birth_date: 0}
+	if err := users.Validate(&valid); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	users.Update(valid)
+	taken := valid
+	taken.Id++
+	validation, ok := users.Validate(&taken).(*model.ValidationError)
+	if !ok || len(validation.Errors) != 1 || validation.Errors[0].Field != "email" {
+		t.Errorf("got %v for a taken email", validation)
+	}
+}
+
+func TestUsersIndexRebuild(t *testing.T) {
+	t.Parallel()
+	users := newUsersMap(0, false)
+	users.Update(model.User{Id: 1, Email: "1@example.com", Gender: "m"})
+	users.Search(UserSearchFilter{Sort: "id", Page: Page{Limit: 1}})
+
+	for id := uint(2); id <= usersIndexMaxChanged+2; id++ {
+		users.Update(model.User{Id: id, Email: strconv.Itoa(int(id)) + "@example.com", Gender: "f"})
+	}
+	if len(users.index.changed) != usersIndexMaxChanged+1 {
+		t.Fatalf("got %d changed users", len(users.index.changed))
+	}
+	page, total := users.Search(UserSearchFilter{Sort: "id", Page: Page{Offset: 1, Limit: 2}})
+	if total != usersIndexMaxChanged+2 || len(page) != 2 || page[0].Id != 2 || page[1].Id != 3 {
+		t.Errorf("got %v of %d", page, total)
+	}
+	if len(users.index.changed) != 0 || len(users.index.ids) != total {
+		t.Errorf("index was not rebuilt: %d changed, %d ids", len(users.index.changed), len(users.index.ids))
+	}
+}
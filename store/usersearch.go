@@ -0,0 +1,260 @@
+package store
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/disc/highloadcup/model"
+)
+
+// UserSearchFilter narrows GET /users. EmailDomain and the name prefixes
+// have to be lowercased; Sort is id, birth_date, first_name or last_name.
+type UserSearchFilter struct {
+	Gender        *string
+	EmailDomain   *string
+	FirstName     *string
+	LastName      *string
+	FromBirthDate *int
+	ToBirthDate   *int
+
+	Sort string
+	Page Page
+}
+
+type userNameKey struct {
+	name string
+	id   uint
+}
+
+type userBirthDateKey struct {
+	birthDate int
+	id        uint
+}
+
+// usersIndexMaxChanged is how many users may change before the next search
+// rebuilds the sorted slices of the index.
+const usersIndexMaxChanged = 1024
+
+// usersIndex holds the secondary indexes behind GET /users. Names are
+// indexed lowercased so prefix lookups are case-insensitive; every sorted
+// slice breaks ties by id.
+//
+// Writes keep the gender and domain sets current but leave the sorted slices
+// alone, as inserting into them costs a copy of the slice. The users written
+// since the slices were built are in changed instead: their entries in the
+// slices are skipped and they are checked on their own.
+type usersIndex struct {
+	ids         []uint
+	byGender    map[string]map[uint]struct{}
+	byDomain    map[string]map[uint]struct{}
+	byFirstName []userNameKey
+	byLastName  []userNameKey
+	byBirthDate []userBirthDateKey
+	changed     map[uint]struct{}
+}
+
+func emailDomain(email string) string {
+	return strings.ToLower(email[strings.LastIndex(email, "@")+1:])
+}
+
+func newUsersIndex(users *UsersMap) *usersIndex {
+	index := &usersIndex{
+		ids:         make([]uint, 0, users.Len()),
+		byGender:    make(map[string]map[uint]struct{}),
+		byDomain:    make(map[string]map[uint]struct{}),
+		byFirstName: make([]userNameKey, 0, users.Len()),
+		byLastName:  make([]userNameKey, 0, users.Len()),
+		byBirthDate: make([]userBirthDateKey, 0, users.Len()),
+		changed:     make(map[uint]struct{}),
+	}
+	users.each(func(user *model.User) {
+		index.ids = append(index.ids, user.Id)
+		index.addToSets(user)
+		index.byFirstName = append(index.byFirstName, userNameKey{strings.ToLower(user.First_name), user.Id})
+		index.byLastName = append(index.byLastName, userNameKey{strings.ToLower(user.Last_name), user.Id})
+		index.byBirthDate = append(index.byBirthDate, userBirthDateKey{user.Birth_date, user.Id})
+	})
+	sort.Slice(index.ids, func(i, j int) bool { return index.ids[i] < index.ids[j] })
+	sort.Slice(index.byFirstName, func(i, j int) bool { return index.byFirstName[i].less(index.byFirstName[j]) })
+	sort.Slice(index.byLastName, func(i, j int) bool { return index.byLastName[i].less(index.byLastName[j]) })
+	sort.Slice(index.byBirthDate, func(i, j int) bool { return index.byBirthDate[i].less(index.byBirthDate[j]) })
+
+	return index
+}
+
+func (k userNameKey) less(other userNameKey) bool {
+	return k.name < other.name || k.name == other.name && k.id < other.id
+}
+
+func (k userBirthDateKey) less(other userBirthDateKey) bool {
+	return k.birthDate < other.birthDate || k.birthDate == other.birthDate && k.id < other.id
+}
+
+func (index *usersIndex) addToSets(user *model.User) {
+	addToIndex(index.byGender, user.Gender, user.Id)
+	addToIndex(index.byDomain, emailDomain(user.Email), user.Id)
+}
+
+func (index *usersIndex) add(user *model.User) {
+	index.addToSets(user)
+	index.changed[user.Id] = struct{}{}
+}
+
+func (index *usersIndex) remove(user *model.User) {
+	removeFromIndex(index.byGender, user.Gender, user.Id)
+	removeFromIndex(index.byDomain, emailDomain(user.Email), user.Id)
+}
+
+func userNamePrefixRange(keys []userNameKey, prefix string) []userNameKey {
+	from := sort.Search(len(keys), func(i int) bool { return keys[i].name >= prefix })
+	to := from + sort.Search(len(keys)-from, func(i int) bool { return !strings.HasPrefix(keys[from+i].name, prefix) })
+	return keys[from:to]
+}
+
+func (filter *UserSearchFilter) birthDateRange(keys []userBirthDateKey) []userBirthDateKey {
+	from, to := 0, len(keys)
+	if filter.FromBirthDate != nil {
+		from = sort.Search(len(keys), func(i int) bool { return keys[i].birthDate >= *filter.FromBirthDate })
+	}
+	if filter.ToBirthDate != nil {
+		to = sort.Search(len(keys), func(i int) bool { return keys[i].birthDate > *filter.ToBirthDate })
+	}
+	if to < from {
+		to = from
+	}
+	return keys[from:to]
+}
+
+// candidates returns the ids from the most selective index the filter can
+// use, and whether they are already ordered by the requested sort key.
+func (index *usersIndex) candidates(filter *UserSearchFilter) ([]uint, bool) {
+	var (
+		best   = index.ids
+		sorted = filter.Sort == "id"
+		// current is set when best comes from a set rather than from the
+		// sorted slices, which miss the changed users.
+		current bool
+	)
+	useSet := func(set map[uint]struct{}) {
+		if len(set) < len(best) {
+			best = make([]uint, 0, len(set))
+			for id := range set {
+				best = append(best, id)
+			}
+			sorted, current = false, true
+		}
+	}
+	useNames := func(keys []userNameKey, sortKey string) {
+		if len(keys) < len(best) {
+			best = make([]uint, len(keys))
+			for i, key := range keys {
+				best[i] = key.id
+			}
+			sorted, current = filter.Sort == sortKey, false
+		}
+	}
+
+	if filter.EmailDomain != nil {
+		useSet(index.byDomain[*filter.EmailDomain])
+	}
+	if filter.FirstName != nil {
+		useNames(userNamePrefixRange(index.byFirstName, *filter.FirstName), "first_name")
+	}
+	if filter.LastName != nil {
+		useNames(userNamePrefixRange(index.byLastName, *filter.LastName), "last_name")
+	}
+	if filter.FromBirthDate != nil || filter.ToBirthDate != nil {
+		if keys := filter.birthDateRange(index.byBirthDate); len(keys) < len(best) {
+			best = make([]uint, len(keys))
+			for i, key := range keys {
+				best[i] = key.id
+			}
+			sorted, current = filter.Sort == "birth_date", false
+		}
+	}
+	if filter.Gender != nil {
+		useSet(index.byGender[*filter.Gender])
+	}
+
+	if !current && len(index.changed) > 0 {
+		ids := make([]uint, 0, len(best)+len(index.changed))
+		for _, id := range best {
+			if _, ok := index.changed[id]; !ok {
+				ids = append(ids, id)
+			}
+		}
+		for id := range index.changed {
+			ids = append(ids, id)
+		}
+		best, sorted = ids, false
+	}
+	return best, sorted
+}
+
+func (filter *UserSearchFilter) matches(user *model.User) bool {
+	if filter.Gender != nil && user.Gender != *filter.Gender {
+		return false
+	}
+	if filter.EmailDomain != nil && emailDomain(user.Email) != *filter.EmailDomain {
+		return false
+	}
+	if filter.FirstName != nil && !strings.HasPrefix(strings.ToLower(user.First_name), *filter.FirstName) {
+		return false
+	}
+	if filter.LastName != nil && !strings.HasPrefix(strings.ToLower(user.Last_name), *filter.LastName) {
+		return false
+	}
+	if filter.FromBirthDate != nil && user.Birth_date < *filter.FromBirthDate {
+		return false
+	}
+	if filter.ToBirthDate != nil && user.Birth_date > *filter.ToBirthDate {
+		return false
+	}
+	return true
+}
+
+func (filter *UserSearchFilter) less(a *model.User, b *model.User) bool {
+	switch filter.Sort {
+	case "birth_date":
+		return userBirthDateKey{a.Birth_date, a.Id}.less(userBirthDateKey{b.Birth_date, b.Id})
+	case "first_name":
+		return userNameKey{strings.ToLower(a.First_name), a.Id}.less(userNameKey{strings.ToLower(b.First_name), b.Id})
+	case "last_name":
+		return userNameKey{strings.ToLower(a.Last_name), a.Id}.less(userNameKey{strings.ToLower(b.Last_name), b.Id})
+	}
+	return a.Id < b.Id
+}
+
+// Search returns the page of users matching the filter and the total number
+// of matches. The indexes are built on the first search and rebuilt once
+// enough users have changed since.
+func (u *UsersMap) Search(filter UserSearchFilter) ([]model.User, int) {
+	u.RLock()
+	if u.index == nil || len(u.index.changed) > usersIndexMaxChanged {
+		u.RUnlock()
+		u.Lock()
+		if u.index == nil || len(u.index.changed) > usersIndexMaxChanged {
+			u.index = newUsersIndex(u)
+		}
+		u.Unlock()
+		u.RLock()
+	}
+	defer u.RUnlock()
+
+	ids, sorted := u.index.candidates(&filter)
+	matches := make([]*model.User, 0)
+	for _, id := range ids {
+		if user := u.Get(id); filter.matches(user) {
+			matches = append(matches, user)
+		}
+	}
+	if !sorted {
+		sort.Slice(matches, func(i, j int) bool { return filter.less(matches[i], matches[j]) })
+	}
+
+	page := make([]model.User, 0)
+	for i := 0; filter.Page.index(i, len(matches)) != -1; i++ {
+		page = append(page, *matches[filter.Page.index(i, len(matches))])
+	}
+	return page, len(matches)
+}
@@ -0,0 +1,272 @@
+package store
+
+import (
+	"sync"
+	"sync/atomic"
+	"unsafe"
+
+	"github.com/disc/highloadcup/model"
+)
+
+// markTotal sums the marks of the visits to a location.
+type markTotal struct {
+	sum   uint
+	count uint
+}
+
+// VisitsMap also indexes the visits by user and by location. The indexes
+// share the stored pointers, which are replaced rather than written to on an
+// update, and marks keeps the mark total of every location. With a history,
+// movedFrom keeps the ids of the visits moved away from each user, the only
+// ones besides its current visits that can have been the user's before.
+type VisitsMap struct {
+	generation  uint64
+	visits      *idTable
+	history     map[uint][]model.Visit
+	historySize int
+	byUser      map[uint][]*model.Visit
+	byLocation  map[uint][]*model.Visit
+	marks       map[uint]markTotal
+	movedFrom   map[uint]map[uint]struct{}
+	observer    Observer
+	sync.RWMutex
+}
+
+func newVisitsMap(historySize int, dense bool) *VisitsMap {
+	return &VisitsMap{
+		visits:      newIdTable(dense),
+		history:     make(map[uint][]model.Visit),
+		historySize: historySize,
+		byUser:      make(map[uint][]*model.Visit),
+		byLocation:  make(map[uint][]*model.Visit),
+		marks:       make(map[uint]markTotal),
+		movedFrom:   make(map[uint]map[uint]struct{}),
+	}
+}
+
+func (v *VisitsMap) Get(id uint) *model.Visit {
+	return (*model.Visit)(v.visits.load(id))
+}
+
+func (v *VisitsMap) Len() int {
+	return v.visits.len()
+}
+
+// ByUser returns the visits of the user.
+func (v *VisitsMap) ByUser(userId uint) []*model.Visit {
+	v.RLock()
+	defer v.RUnlock()
+
+	return v.byUser[userId]
+}
+
+// ByLocation returns the visits to the location.
+func (v *VisitsMap) ByLocation(locationId uint) []*model.Visit {
+	v.RLock()
+	defer v.RUnlock()
+
+	return v.byLocation[locationId]
+}
+
+// LocationMarks returns the sum and the number of the marks of the visits to
+// the location.
+func (v *VisitsMap) LocationMarks(locationId uint) (uint, uint) {
+	v.RLock()
+	defer v.RUnlock()
+
+	total := v.marks[locationId]
+	return total.sum, total.count
+}
+
+// GetAsOf returns the revision of the visit that was current at the given
+// unix time, or nil if it did not exist yet or has aged out of the history.
+func (v *VisitsMap) GetAsOf(id uint, at int) *model.Visit {
+	v.RLock()
+	defer v.RUnlock()
+
+	return v.getAsOf(id, at)
+}
+
+func (v *VisitsMap) getAsOf(id uint, at int) *model.Visit {
+	visit := v.Get(id)
+	if visit == nil || visit.UpdatedAt <= at {
+		return visit
+	}
+	revisions := v.history[id]
+	for i := len(revisions) - 1; i >= 0; i-- {
+		if revisions[i].UpdatedAt <= at {
+			revision := revisions[i]
+			return &revision
+		}
+	}
+	return nil
+}
+
+// History returns the retained revisions of the visit, oldest first and
+// ending with the current one.
+func (v *VisitsMap) History(id uint) []model.Visit {
+	v.RLock()
+	defer v.RUnlock()
+
+	visit := v.Get(id)
+	if visit == nil {
+		return nil
+	}
+	return append(append([]model.Visit{}, v.history[id]...), *visit)
+}
+
+// UserVisitsAsOf returns the visits that belonged to the user at the given
+// unix time, as they were then. Besides the user's current visits only
+// visits that moved away from the user can have belonged to it.
+func (v *VisitsMap) UserVisitsAsOf(userId uint, at int) []*model.Visit {
+	v.RLock()
+	defer v.RUnlock()
+
+	var (
+		visits = make([]*model.Visit, 0, len(v.byUser[userId]))
+		seen   = make(map[uint]bool)
+	)
+	collect := func(id uint) {
+		if seen[id] {
+			return
+		}
+		seen[id] = true
+		if visit := v.getAsOf(id, at); visit != nil && uint(visit.User) == userId {
+			revision := *visit
+			visits = append(visits, &revision)
+		}
+	}
+	for _, visit := range v.byUser[userId] {
+		collect(uint(visit.Id))
+	}
+	for id := range v.movedFrom[userId] {
+		collect(id)
+	}
+	return visits
+}
+
+// Update stores the visit and returns its new version along with the
+// visit it replaced, if any.
+func (v *VisitsMap) Update(visit model.Visit) (uint, *model.Visit) {
+	v.Lock()
+	defer v.Unlock()
+
+	return v.update(visit)
+}
+
+// UpdateIfVersion stores the visit only if the stored one is still at the
+// given version, and returns the visit it replaced or ErrVersionMismatch.
+func (v *VisitsMap) UpdateIfVersion(visit model.Visit, version uint) (*model.Visit, error) {
+	v.Lock()
+	defer v.Unlock()
+
+	if prev := v.Get(uint(visit.Id)); prev == nil || uint(prev.Version) != version {
+		return nil, ErrVersionMismatch
+	}
+	_, prev := v.update(visit)
+	return prev, nil
+}
+
+// update stores the visit and keeps the by-user and by-location indexes in
+// sync. Every revision is a new record swapped in for the stored one, and an
+// index slice is copied rather than changed where readers may still hold it,
+// so the records and slices handed out are never written to.
+func (v *VisitsMap) update(visit model.Visit) (uint, *model.Visit) {
+	atomic.AddUint64(&v.generation, 1)
+	stored := v.Get(uint(visit.Id))
+	if stored == nil {
+		visit.Version = 1
+		next := &visit
+		v.visits.store(uint(visit.Id), unsafe.Pointer(next))
+		v.byUser[uint(visit.User)] = append(v.byUser[uint(visit.User)], next)
+		v.byLocation[uint(visit.Location)] = append(v.byLocation[uint(visit.Location)], next)
+		v.addMark(&visit, 1)
+		if v.observer != nil {
+			v.observer("visit", uint(visit.Id), uint(visit.Version), visit, nil)
+		}
+		return uint(visit.Version), nil
+	}
+
+	visit.Version = stored.Version + 1
+	if v.historySize > 0 {
+		revisions := append(v.history[uint(visit.Id)], *stored)
+		if len(revisions) > v.historySize {
+			revisions = revisions[len(revisions)-v.historySize:]
+		}
+		v.history[uint(visit.Id)] = revisions
+	}
+	next := &visit
+	v.visits.store(uint(visit.Id), unsafe.Pointer(next))
+	if stored.User != visit.User {
+		if v.historySize > 0 {
+			addIdToIndex(v.movedFrom, uint(stored.User), uint(visit.Id))
+		}
+		v.byUser[uint(stored.User)] = removeVisit(v.byUser[uint(stored.User)], stored)
+		v.byUser[uint(visit.User)] = append(v.byUser[uint(visit.User)], next)
+	} else {
+		v.byUser[uint(visit.User)] = replaceVisit(v.byUser[uint(visit.User)], stored, next)
+	}
+	if stored.Location != visit.Location {
+		v.byLocation[uint(stored.Location)] = removeVisit(v.byLocation[uint(stored.Location)], stored)
+		v.byLocation[uint(visit.Location)] = append(v.byLocation[uint(visit.Location)], next)
+	} else {
+		v.byLocation[uint(visit.Location)] = replaceVisit(v.byLocation[uint(visit.Location)], stored, next)
+	}
+	v.addMark(stored, -1)
+	v.addMark(&visit, 1)
+	if v.observer != nil {
+		v.observer("visit", uint(visit.Id), uint(visit.Version), visit, *stored)
+	}
+	return uint(visit.Version), stored
+}
+
+func (v *VisitsMap) Observe(observer Observer) {
+	v.Lock()
+	defer v.Unlock()
+
+	v.observer = observer
+}
+
+// addMark adds the mark of the visit to the total of its location, or takes
+// it away with a sign of -1.
+func (v *VisitsMap) addMark(visit *model.Visit, sign int) {
+	total := v.marks[uint(visit.Location)]
+	total.sum = uint(int(total.sum) + sign*int(visit.Mark))
+	total.count = uint(int(total.count) + sign)
+	if total.count == 0 {
+		delete(v.marks, uint(visit.Location))
+		return
+	}
+	v.marks[uint(visit.Location)] = total
+}
+
+// Generation changes on every visit write, so results derived from the
+// visit indexes can be cached until it moves on.
+func (v *VisitsMap) Generation() uint64 {
+	return atomic.LoadUint64(&v.generation)
+}
+
+// removeVisit returns a copy of the visits without the given one. Appending
+// to an index slice in place is fine, as readers never look past their
+// length, but changing its elements is not.
+func removeVisit(visits []*model.Visit, visit *model.Visit) []*model.Visit {
+	for key, v := range visits {
+		if v == visit {
+			removed := make([]*model.Visit, 0, len(visits)-1)
+			return append(append(removed, visits[:key]...), visits[key+1:]...)
+		}
+	}
+	return visits
+}
+
+// replaceVisit returns a copy of the visits with the given one replaced.
+func replaceVisit(visits []*model.Visit, visit *model.Visit, next *model.Visit) []*model.Visit {
+	for key, v := range visits {
+		if v == visit {
+			replaced := append([]*model.Visit{}, visits...)
+			replaced[key] = next
+			return replaced
+		}
+	}
+	return visits
+}
@@ -0,0 +1,77 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/disc/highloadcup/model"
+)
+
+// A visit that moved to another user is still found among the visits of its
+// previous user as of before the move, without scanning every visit.
+func TestUserVisitsAsOfMovedVisit(t *testing.T) {
+	t.Parallel()
+	s := NewStore(16)
+	s.Visits.Update(model.Visit{Id: 1, Location: 10, User: 20, Mark: 1, UpdatedAt: 100})
+	s.Visits.Update(model.Visit{Id: 2, Location: 10, User: 22, Mark: 1, UpdatedAt: 100})
+	s.Visits.Update(model.Visit{Id: 1, Location: 10, User: 21, Mark: 2, UpdatedAt: 200})
+	s.Visits.Update(model.Visit{Id: 2, Location: 10, User: 22, Mark: 5, UpdatedAt: 200})
+
+	if visits := s.Visits.UserVisitsAsOf(20, 150); len(visits) != 1 || visits[0].Id != 1 || visits[0].Mark != 1 {
+		t.Errorf("got %v for the previous owner", visits)
+	}
+	if visits := s.Visits.UserVisitsAsOf(20, 250); len(visits) != 0 {
+		t.Errorf("got %v after the move", visits)
+	}
+	if visits := s.Visits.UserVisitsAsOf(21, 150); len(visits) != 0 {
+		t.Errorf("got %v before the move", visits)
+	}
+	if visits := s.Visits.UserVisitsAsOf(22, 150); len(visits) != 1 || visits[0].Mark != 1 {
+		t.Errorf("got %v for an edited visit", visits)
+	}
+}
+
+// Readers take visits and the index slices without the lock, so updates
+// must never write to a record or a slice handed out before. Run with -race.
+func TestVisitReadsDuringUpdates(t *testing.T) {
+	t.Parallel()
+	for _, dense := range []bool{false, true} {
+		s := newStore(0, dense)
+		for id := uint32(1); id <= 100; id++ {
+			s.Visits.Update(model.Visit{Id: id, Location: id % 10, User: id % 7, Mark: 1})
+		}
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for i := uint32(0); i < 2000; i++ {
+				id := i%100 + 1
+				s.Visits.Update(model.Visit{Id: id, Location: i % 10, User: i % 7, Mark: uint8(i % 6)})
+			}
+		}()
+
+		for reading := true; reading; {
+			select {
+			case <-done:
+				reading = false
+			default:
+			}
+			for id := uint(1); id <= 100; id++ {
+				if visit := s.Visits.Get(id); visit == nil || visit.Mark > 5 {
+					t.Fatalf("dense %v: got %v for %d", dense, visit, id)
+				}
+			}
+			for key := uint(0); key < 10; key++ {
+				for _, visit := range s.Visits.ByLocation(key) {
+					if uint(visit.Location) != key || visit.Mark > 5 {
+						t.Fatalf("dense %v: got %v at location %d", dense, visit, key)
+					}
+				}
+				for _, visit := range s.Visits.ByUser(key) {
+					if uint(visit.User) != key {
+						t.Fatalf("dense %v: got %v of user %d", dense, visit, key)
+					}
+				}
+			}
+		}
+	}
+}
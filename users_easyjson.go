@@ -1,126 +0,0 @@
-// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
-
-package main
-
-import (
-	json "encoding/json"
-	easyjson "github.com/mailru/easyjson"
-	jlexer "github.com/mailru/easyjson/jlexer"
-	jwriter "github.com/mailru/easyjson/jwriter"
-)
-
-// suppress unused package warning
-var (
-	_ *json.RawMessage
-	_ *jlexer.Lexer
-	_ *jwriter.Writer
-	_ easyjson.Marshaler
-)
-
-func easyjson84c0690eDecodeGithubComDiscHighloadcup(in *jlexer.Lexer, out *User) {
-	isTopLevel := in.IsStart()
-	if in.IsNull() {
-		if isTopLevel {
-			in.Consumed()
-		}
-		in.Skip()
-		return
-	}
-	in.Delim('{')
-	for !in.IsDelim('}') {
-		key := in.UnsafeString()
-		in.WantColon()
-		if in.IsNull() {
-			in.Skip()
-			in.WantComma()
-			continue
-		}
-		switch key {
-		case "id":
-			out.Id = uint(in.Uint())
-		case "email":
-			out.Email = string(in.String())
-		case "first_name":
-			out.First_name = string(in.String())
-		case "last_name":
-			out.Last_name = string(in.String())
-		case "gender":
-			out.Gender = string(in.String())
-		case "birth_date":
-			out.Birth_date = int(in.Int())
-		default:
-			in.SkipRecursive()
-		}
-		in.WantComma()
-	}
-	in.Delim('}')
-	if isTopLevel {
-		in.Consumed()
-	}
-}
-func easyjson84c0690eEncodeGithubComDiscHighloadcup(out *jwriter.Writer, in User) {
-	out.RawByte('{')
-	first := true
-	_ = first
-	if !first {
-		out.RawByte(',')
-	}
-	first = false
-	out.RawString("\"id\":")
-	out.Uint(uint(in.Id))
-	if !first {
-		out.RawByte(',')
-	}
-	first = false
-	out.RawString("\"email\":")
-	out.String(string(in.Email))
-	if !first {
-		out.RawByte(',')
-	}
-	first = false
-	out.RawString("\"first_name\":")
-	out.String(string(in.First_name))
-	if !first {
-		out.RawByte(',')
-	}
-	first = false
-	out.RawString("\"last_name\":")
-	out.String(string(in.Last_name))
-	if !first {
-		out.RawByte(',')
-	}
-	first = false
-	out.RawString("\"gender\":")
-	out.String(string(in.Gender))
-	if !first {
-		out.RawByte(',')
-	}
-	first = false
-	out.RawString("\"birth_date\":")
-	out.Int(int(in.Birth_date))
-	out.RawByte('}')
-}
-
-// MarshalJSON supports json.Marshaler interface
-func (v User) MarshalJSON() ([]byte, error) {
-	w := jwriter.Writer{}
-	easyjson84c0690eEncodeGithubComDiscHighloadcup(&w, v)
-	return w.Buffer.BuildBytes(), w.Error
-}
-
-// MarshalEasyJSON supports easyjson.Marshaler interface
-func (v User) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson84c0690eEncodeGithubComDiscHighloadcup(w, v)
-}
-
-// UnmarshalJSON supports json.Unmarshaler interface
-func (v *User) UnmarshalJSON(data []byte) error {
-	r := jlexer.Lexer{Data: data}
-	easyjson84c0690eDecodeGithubComDiscHighloadcup(&r, v)
-	return r.Error()
-}
-
-// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *User) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson84c0690eDecodeGithubComDiscHighloadcup(l, v)
-}